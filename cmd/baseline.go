@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/arsiba/tofulint/tflint"
+	"github.com/spf13/afero"
+)
+
+// writeBaseline records the passed issues to the baseline file.
+func (cli *CLI) writeBaseline(file string, issues tflint.Issues) error {
+	if err := tflint.NewBaseline(issues).Write(afero.Afero{Fs: afero.NewOsFs()}, cli.baselinePath(file)); err != nil {
+		return fmt.Errorf("Failed to write baseline; %w", err)
+	}
+	fmt.Fprintf(cli.errStream, "Baseline with %d issue(s) written to %s\n", len(issues), file)
+	return nil
+}
+
+// filterByBaseline returns issues that are not recorded in the baseline file.
+// Baseline entries that no longer match any issue are reported to stderr,
+// so that they do not interfere with machine-readable output formats.
+func (cli *CLI) filterByBaseline(file string, issues tflint.Issues) (tflint.Issues, error) {
+	baseline, err := tflint.LoadBaseline(afero.Afero{Fs: afero.NewOsFs()}, cli.baselinePath(file))
+	if err != nil {
+		return issues, fmt.Errorf("Failed to load baseline; %w", err)
+	}
+
+	issues, stale := baseline.Filter(issues)
+	if len(stale) > 0 {
+		fmt.Fprintf(cli.errStream, "%d baseline entry(s) no longer found. Run with --write-baseline to update %s:\n", len(stale), file)
+		for _, entry := range stale {
+			fmt.Fprintf(cli.errStream, "  %s: %s (%s)\n", entry.Filename, entry.Message, entry.Rule)
+		}
+	}
+	return issues, nil
+}
+
+// baselinePath resolves the baseline file against the original working directory,
+// so that the same file is used for all working directories in recursive inspection.
+func (cli *CLI) baselinePath(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(cli.originalWorkingDir, file)
+}
@@ -10,7 +10,7 @@ import (
 	"strings"
 
 	"github.com/arsiba/tofulint/formatter"
-	"github.com/arsiba/tofulint/opentofu"
+	"github.com/arsiba/tofulint/gitdiff"
 	"github.com/arsiba/tofulint/tflint"
 	"github.com/fatih/color"
 	"github.com/hashicorp/logutils"
@@ -31,11 +31,8 @@ type CLI struct {
 	outStream, errStream io.Writer
 	originalWorkingDir   string
 	sources              map[string][]byte
-
-	// fields for each module
-	config    *tflint.Config
-	loader    *opentofu.Loader
-	formatter *formatter.Formatter
+	diffBase             *gitdiff.Repository
+	formatter            *formatter.Formatter
 }
 
 // NewCLI returns new CLI initialized by input streams
@@ -62,7 +59,10 @@ func (cli *CLI) Run(args []string) int {
 	cli.formatter = &formatter.Formatter{
 		Stdout: cli.outStream,
 		Stderr: cli.errStream,
-		Format: opts.Format,
+	}
+	outputs, formatErr := formatter.ParseOutputs(opts.Format)
+	if formatErr == nil {
+		cli.formatter.Outputs = outputs
 	}
 	if opts.Color {
 		color.NoColor = false
@@ -88,6 +88,10 @@ func (cli *CLI) Run(args []string) int {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", err), map[string][]byte{})
 		return ExitCodeError
 	}
+	if formatErr != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", formatErr), map[string][]byte{})
+		return ExitCodeError
+	}
 	if len(args) > 1 {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Command line arguments support was dropped in v0.47. Use --chdir or --filter instead."), map[string][]byte{})
 		return ExitCodeError
@@ -100,6 +104,8 @@ func (cli *CLI) Run(args []string) int {
 		return cli.init(opts)
 	case opts.Langserver:
 		return cli.startLanguageServer(opts)
+	case opts.ValidateConfig:
+		return cli.validateConfig(opts)
 	case opts.ActAsBundledPlugin:
 		return cli.actAsBundledPlugin()
 	default:
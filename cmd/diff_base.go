@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/arsiba/tofulint/gitdiff"
+	"github.com/arsiba/tofulint/opentofu"
+	"github.com/arsiba/tofulint/opentofu/addrs"
+	"github.com/arsiba/tofulint/tflint"
+)
+
+// filterByDiffBase returns issues whose range overlaps lines changed since the base revision.
+func (cli *CLI) filterByDiffBase(issues tflint.Issues) (tflint.Issues, error) {
+	if cli.diffBase == nil {
+		return issues, nil
+	}
+
+	ret := tflint.Issues{}
+	for _, issue := range issues {
+		lines, err := cli.diffBase.ChangedLines(filepath.Join(cli.originalWorkingDir, issue.Range.Filename))
+		if err != nil {
+			return ret, fmt.Errorf("Failed to compare %s with the base revision; %w", issue.Range.Filename, err)
+		}
+
+		issueLines := gitdiff.LineRange{Start: issue.Range.Start.Line, End: issue.Range.End.Line}
+		for _, changed := range lines {
+			if changed.Overlaps(issueLines) {
+				ret = append(ret, issue)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+// moduleChanged returns true if the module or any local module it calls
+// has changed files since the base revision.
+// Remote modules are not tracked by the repository, so they are not considered.
+// The module directories are resolved against the passed working directory.
+func (cli *CLI) moduleChanged(wd string, cfg *opentofu.Config) (bool, error) {
+	if cli.diffBase == nil {
+		return true, nil
+	}
+
+	dir := filepath.Join(wd, cfg.Module.SourceDir)
+	changed, err := cli.diffBase.DirChanged(dir, ".tf", ".tofu")
+	if err != nil {
+		return false, fmt.Errorf("Failed to compare %s with the base revision; %w", dir, err)
+	}
+	if changed {
+		return true, nil
+	}
+
+	for name, child := range cfg.Children {
+		if _, local := cfg.Module.ModuleCalls[name].SourceAddr.(addrs.ModuleSourceLocal); !local {
+			continue
+		}
+		changed, err := cli.moduleChanged(wd, child)
+		if err != nil || changed {
+			return changed, err
+		}
+	}
+	return false, nil
+}
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 
+	"github.com/arsiba/tofulint/formatter"
+	"github.com/arsiba/tofulint/gitdiff"
 	"github.com/arsiba/tofulint/opentofu"
 	"github.com/arsiba/tofulint/plugin"
 	"github.com/arsiba/tofulint/tflint"
@@ -19,8 +25,18 @@ import (
 )
 
 func (cli *CLI) inspect(opts Options) int {
-	// Respect the "--format" flag until a config is loaded
-	cli.formatter.Format = opts.Format
+	if opts.WriteBaseline && opts.Baseline == "" {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--write-baseline requires --baseline to specify the file to write"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if opts.DryRun && !opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--dry-run requires --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
+	if len(opts.FixRules) > 0 && !opts.Fix {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--fix-rule requires --fix"), map[string][]byte{})
+		return ExitCodeError
+	}
 
 	workingDirs, err := findWorkingDirs(opts)
 	if err != nil {
@@ -28,50 +44,63 @@ func (cli *CLI) inspect(opts Options) int {
 		return ExitCodeError
 	}
 
+	if opts.DiffBase != "" {
+		cli.diffBase, err = gitdiff.Open(cli.originalWorkingDir, opts.DiffBase)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to read the git repository; %w", err), map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+
 	issues := tflint.Issues{}
 	changes := map[string][]byte{}
+	var config *tflint.Config
 
-	for _, wd := range workingDirs {
-		err := cli.withinChangedDir(wd, func() error {
-			filterFiles := []string{}
-			for _, pattern := range opts.Filter {
-				files, err := filepath.Glob(pattern)
-				if err != nil {
-					return fmt.Errorf("Failed to parse --filter options; %w", err)
-				}
-				// Add the raw pattern to return an empty result if it doesn't match any files
-				if len(files) == 0 {
-					filterFiles = append(filterFiles, pattern)
-				}
-				filterFiles = append(filterFiles, files...)
+	for i, result := range cli.inspectWorkingDirs(opts, workingDirs) {
+		for path, source := range result.sources {
+			cli.sources[path] = source
+		}
+		if result.err != nil {
+			err := result.err
+			if len(workingDirs) > 1 {
+				// Print the current working directory in recursive inspection
+				err = fmt.Errorf("%w working_dir=%s", err, workingDirs[i])
 			}
+			cli.formatter.Print(tflint.Issues{}, err, result.sources)
+			return ExitCodeError
+		}
 
-			// Join with the working directory to create the fullpath
-			for i, file := range filterFiles {
-				filterFiles[i] = filepath.Join(wd, file)
-			}
+		issues = append(issues, result.issues...)
+		for path, source := range result.changes {
+			changes[path] = source
+		}
+		config = result.config
+	}
 
-			moduleIssues, moduleChanges, err := cli.inspectModule(opts, ".", filterFiles)
-			if err != nil {
-				if len(workingDirs) > 1 {
-					// Print the current working directory in recursive inspection
-					return fmt.Errorf("%w working_dir=%s", err, wd)
-				}
-				return err
-			}
-			issues = append(issues, moduleIssues...)
-			for path, source := range moduleChanges {
-				changes[path] = source
+	// Issues ignored by annotations are only passed to the formatter, so they never affect the exit status or baselines
+	suppressed := tflint.Issues{}
+	reported := tflint.Issues{}
+	for _, issue := range issues {
+		if issue.SuppressedBy != nil {
+			suppressed = append(suppressed, issue)
+		} else {
+			reported = append(reported, issue)
+		}
+	}
+	issues = reported
+
+	if opts.Baseline != "" {
+		if opts.WriteBaseline {
+			if err := cli.writeBaseline(opts.Baseline, issues); err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+				return ExitCodeError
 			}
+			return ExitCodeOK
+		}
 
-			return nil
-		})
+		issues, err = cli.filterByBaseline(opts.Baseline, issues)
 		if err != nil {
-			sources := map[string][]byte{}
-			if cli.loader != nil {
-				sources = cli.loader.Sources()
-			}
-			cli.formatter.Print(tflint.Issues{}, err, sources)
+			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
 			return ExitCodeError
 		}
 	}
@@ -79,19 +108,35 @@ func (cli *CLI) inspect(opts Options) int {
 	var force bool
 	if opts.Recursive {
 		// Respect "--format" and "--force" flags in recursive mode
-		cli.formatter.Format = opts.Format
 		if opts.Force != nil {
 			force = *opts.Force
 		}
 	} else {
-		cli.formatter.Format = cli.config.Format
-		force = cli.config.Force
+		// The "--format" flag takes precedence over the config, as it is merged into the config
+		outputs, err := formatter.ParseOutputs(config.Formats)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
+			return ExitCodeError
+		}
+		cli.formatter.Outputs = outputs
+		force = config.Force
 	}
 
-	cli.formatter.Fix = opts.Fix
+	// In dry-run mode, changes are shown as a diff and issues remain fixable
+	cli.formatter.Fix = opts.Fix && !opts.DryRun
+	cli.formatter.Changes = changes
+	cli.formatter.Diff = opts.DryRun
+	cli.formatter.FixRules = opts.FixRules
+	cli.formatter.Suppressed = suppressed
 	cli.formatter.Print(issues, nil, cli.sources)
 
-	if opts.Fix {
+	if opts.DryRun {
+		for path, source := range changes {
+			if !bytes.Equal(source, cli.sources[path]) {
+				return ExitCodeIssuesFound
+			}
+		}
+	} else if opts.Fix {
 		if err := writeChanges(changes); err != nil {
 			cli.formatter.Print(tflint.Issues{}, err, cli.sources)
 			return ExitCodeError
@@ -105,59 +150,146 @@ func (cli *CLI) inspect(opts Options) int {
 	return ExitCodeOK
 }
 
-func (cli *CLI) inspectModule(opts Options, dir string, filterFiles []string) (tflint.Issues, map[string][]byte, error) {
-	issues := tflint.Issues{}
-	changes := map[string][]byte{}
-	var err error
+// inspectResult is the result of inspecting a working directory.
+type inspectResult struct {
+	issues  tflint.Issues
+	changes map[string][]byte
+	sources map[string][]byte
+	config  *tflint.Config
+	err     error
+}
+
+// inspectWorkingDirs inspects the working directories with the number of workers
+// specified by --jobs, and returns the results in the same order as the directories.
+//
+// Launched plugins are shared between workers through the pool,
+// so plugin processes are not restarted for every directory.
+func (cli *CLI) inspectWorkingDirs(opts Options, workingDirs []string) []*inspectResult {
+	results := make([]*inspectResult, len(workingDirs))
+
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	if jobs > len(workingDirs) {
+		jobs = len(workingDirs)
+	}
+
+	queue := make(chan int, len(workingDirs))
+	for i := range workingDirs {
+		queue <- i
+	}
+	close(queue)
+
+	plugins := &pluginPool{maxIdle: jobs}
+	defer plugins.clean()
+
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				results[i] = cli.inspectWorkingDir(opts, workingDirs[i], plugins)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (cli *CLI) inspectWorkingDir(opts Options, wd string, plugins *pluginPool) *inspectResult {
+	result := &inspectResult{
+		issues:  tflint.Issues{},
+		changes: map[string][]byte{},
+		sources: map[string][]byte{},
+	}
+
+	filterFiles := []string{}
+	for _, pattern := range opts.Filter {
+		// Join with the working directory to create the fullpath
+		pattern = filepath.Join(wd, pattern)
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			result.err = fmt.Errorf("Failed to parse --filter options; %w", err)
+			return result
+		}
+		// Add the raw pattern to return an empty result if it doesn't match any files
+		if len(files) == 0 {
+			filterFiles = append(filterFiles, pattern)
+		}
+		filterFiles = append(filterFiles, files...)
+	}
 
 	// Setup config
-	cli.config, err = tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	fs := afero.Afero{Fs: opentofu.NewWorkingDirFs(afero.NewOsFs(), wd)}
+	config, err := tflint.LoadConfig(fs, opts.Config)
 	if err != nil {
-		return issues, changes, fmt.Errorf("Failed to load TofuLint config; %w", err)
+		result.err = fmt.Errorf("Failed to load TofuLint config; %w", err)
+		return result
 	}
-	cli.config.Merge(opts.toConfig())
+	config.Merge(opts.toConfig())
+	result.config = config
 
 	// Setup loader
-	cli.loader, err = opentofu.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir)
+	loader, err := opentofu.NewLoaderInDir(afero.Afero{Fs: afero.NewOsFs()}, cli.originalWorkingDir, wd)
 	if err != nil {
-		return issues, changes, fmt.Errorf("Failed to prepare loading; %w", err)
+		result.err = fmt.Errorf("Failed to prepare loading; %w", err)
+		return result
 	}
-	if opts.Recursive && !cli.loader.IsConfigDir(dir) {
+	result.issues, result.changes, result.err = cli.inspectModule(opts, wd, ".", filterFiles, config, loader, plugins)
+	result.sources = loader.Sources()
+
+	return result
+}
+
+func (cli *CLI) inspectModule(opts Options, wd string, dir string, filterFiles []string, config *tflint.Config, loader *opentofu.Loader, plugins *pluginPool) (tflint.Issues, map[string][]byte, error) {
+	issues := tflint.Issues{}
+	changes := map[string][]byte{}
+	var err error
+
+	if opts.Recursive && !loader.IsConfigDir(dir) {
 		// Ignore non-module directories in recursive mode
 		return issues, changes, nil
 	}
 
 	// Setup runners
-	rootRunner, moduleRunners, err := cli.setupRunners(opts, dir)
+	rootRunner, moduleRunners, err := setupRunners(dir, config, loader)
 	if err != nil {
 		return issues, changes, err
 	}
+	runners := slices.Concat(moduleRunners, []*tflint.Runner{rootRunner})
+	if opts.Recursive {
+		changed, err := cli.moduleChanged(wd, rootRunner.TFConfig)
+		if err != nil {
+			return issues, changes, err
+		}
+		if !changed {
+			// Ignore modules that have not changed since the base revision in recursive mode
+			return issues, changes, nil
+		}
+	}
 
 	// Launch plugin processes
-	rulesetPlugin, err := launchPlugins(cli.config, opts.Fix)
-	if rulesetPlugin != nil {
-		defer rulesetPlugin.Clean()
+	absWd := wd
+	if !filepath.IsAbs(absWd) {
+		absWd = filepath.Join(cli.originalWorkingDir, wd)
 	}
+	if err := plugin.ResolvePluginDir(config, absWd); err != nil {
+		return issues, changes, fmt.Errorf("Failed to initialize plugins; %w", err)
+	}
+	pooled, err := plugins.acquire(config)
 	if err != nil {
 		return issues, changes, err
 	}
+	defer plugins.release(pooled)
+	rulesetPlugin, sdkVersions := pooled.plugin, pooled.sdkVersions
 
-	// Check preconditions
-	sdkVersions := map[string]*version.Version{}
-	for name, ruleset := range rulesetPlugin.RuleSets {
-		sdkVersion, err := ruleset.SDKVersion()
-		if err != nil {
-			if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
-				// SDKVersion endpoint is available in tflint-plugin-sdk v0.14+.
-				return issues, changes, fmt.Errorf(`Plugin "%s" SDK version is incompatible. Compatible versions: %s`, name, plugin.SDKVersionConstraints)
-			} else {
-				return issues, changes, fmt.Errorf(`Failed to get plugin "%s" SDK version; %w`, name, err)
-			}
-		}
-		if !plugin.SDKVersionConstraints.Check(sdkVersion) {
-			return issues, changes, fmt.Errorf(`Plugin "%s" SDK version (%s) is incompatible. Compatible versions: %s`, name, sdkVersion, plugin.SDKVersionConstraints)
-		}
-		sdkVersions[name] = sdkVersion
+	// Variable validations do not depend on rulesets, so they are checked only once
+	for _, runner := range runners {
+		runner.EmitVariableValidationIssues()
 	}
 
 	// Run inspection
@@ -175,7 +307,7 @@ By setting TFLINT_LOG=trace, you can confirm the changes made by the autofix and
 		}
 
 		for name, ruleset := range rulesetPlugin.RuleSets {
-			if err := ruleset.Check(plugin.NewGRPCServer(rootRunner, rootRunner, cli.loader.Files(), sdkVersions[name])); err != nil {
+			if err := ruleset.Check(plugin.NewGRPCServer(rootRunner, rootRunner, loader.Files(), sdkVersions[name])); err != nil {
 				return issues, changes, fmt.Errorf("Failed to check ruleset; %w", err)
 			}
 			// Run checks for module calls are performed in parallel.
@@ -184,10 +316,10 @@ By setting TFLINT_LOG=trace, you can confirm the changes made by the autofix and
 			ch := make(chan error, len(moduleRunners))
 			for _, runner := range moduleRunners {
 				if opts.NoParallelRunners {
-					ch <- ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, cli.loader.Files(), sdkVersions[name]))
+					ch <- ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, loader.Files(), sdkVersions[name]))
 				} else {
 					go func(runner *tflint.Runner) {
-						ch <- ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, cli.loader.Files(), sdkVersions[name]))
+						ch <- ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, loader.Files(), sdkVersions[name]))
 					}(runner)
 				}
 			}
@@ -201,15 +333,29 @@ By setting TFLINT_LOG=trace, you can confirm the changes made by the autofix and
 		}
 
 		changesInAttempt := map[string][]byte{}
-		for _, runner := range append(moduleRunners, rootRunner) {
-			for _, issue := range runner.LookupIssues(filterFiles...) {
-				// On the second attempt, only fixable issues are appended to avoid duplicates.
-				if loop == 1 || issue.Fixable {
+		for _, runner := range runners {
+			runnerIssues, err := cli.filterByDiffBase(runner.LookupIssues(filterFiles...))
+			if err != nil {
+				return issues, changes, err
+			}
+			for _, issue := range runnerIssues {
+				// On the second attempt, only fixed issues are appended to avoid duplicates.
+				if loop == 1 || (issue.Fixable && config.FixEnabled(issue.Rule.Name())) {
 					issues = append(issues, issue)
 				}
 			}
 			runner.Issues = tflint.Issues{}
 
+			// Suppressed issues are not fixed, so they are appended only on the first attempt.
+			if opts.IncludeSuppressed && loop == 1 {
+				suppressed, err := cli.filterByDiffBase(runner.LookupSuppressedIssues(filterFiles...))
+				if err != nil {
+					return issues, changes, err
+				}
+				issues = append(issues, suppressed...)
+			}
+			runner.SuppressedIssues = tflint.Issues{}
+
 			for path, source := range runner.LookupChanges(filterFiles...) {
 				changesInAttempt[path] = source
 				changes[path] = source
@@ -222,21 +368,38 @@ By setting TFLINT_LOG=trace, you can confirm the changes made by the autofix and
 		}
 	}
 
-	// Set module sources to CLI
-	for path, source := range cli.loader.Sources() {
-		cli.sources[path] = source
+	if opts.ReportUnusedAnnotations {
+		knownRules := tflint.BuiltinRuleNames()
+		for _, ruleset := range rulesetPlugin.RuleSets {
+			ruleNames, err := ruleset.RuleNames()
+			if err != nil {
+				return issues, changes, fmt.Errorf("Failed to get rule names; %w", err)
+			}
+			knownRules = append(knownRules, ruleNames...)
+		}
+
+		// Annotations are shared with module runners, so it is enough to check the root runner
+		rootRunner.EmitUnusedAnnotationIssues(knownRules)
+	}
+	rootRunner.EmitAnnotationPolicyIssues()
+
+	annotationIssues, err := cli.filterByDiffBase(rootRunner.LookupIssues(filterFiles...))
+	if err != nil {
+		return issues, changes, err
 	}
+	issues = append(issues, annotationIssues...)
+	rootRunner.Issues = tflint.Issues{}
 
 	return issues, changes, nil
 }
 
-func (cli *CLI) setupRunners(opts Options, dir string) (*tflint.Runner, []*tflint.Runner, error) {
-	configs, diags := cli.loader.LoadConfig(dir, cli.config.CallModuleType)
+func setupRunners(dir string, config *tflint.Config, loader *opentofu.Loader) (*tflint.Runner, []*tflint.Runner, error) {
+	configs, diags := loader.LoadConfig(dir, config.CallModuleType)
 	if diags.HasErrors() {
 		return nil, []*tflint.Runner{}, fmt.Errorf("Failed to load configurations; %w", diags)
 	}
 
-	files, diags := cli.loader.LoadConfigDirFiles(dir)
+	files, diags := loader.LoadConfigDirFiles(dir)
 	if diags.HasErrors() {
 		return nil, []*tflint.Runner{}, fmt.Errorf("Failed to load configurations; %w", diags)
 	}
@@ -253,17 +416,17 @@ func (cli *CLI) setupRunners(opts Options, dir string) (*tflint.Runner, []*tflin
 		return nil, []*tflint.Runner{}, fmt.Errorf("Failed to load configurations; %w", diags)
 	}
 
-	variables, diags := cli.loader.LoadValuesFiles(dir, cli.config.Varfiles...)
+	variables, diags := loader.LoadValuesFiles(dir, config.Varfiles...)
 	if diags.HasErrors() {
 		return nil, []*tflint.Runner{}, fmt.Errorf("Failed to load values files; %w", diags)
 	}
-	cliVars, diags := opentofu.ParseVariableValues(cli.config.Variables, configs.Module.Variables)
+	cliVars, diags := opentofu.ParseVariableValues(config.Variables, configs.Module.Variables)
 	if diags.HasErrors() {
 		return nil, []*tflint.Runner{}, fmt.Errorf("Failed to parse variables; %w", diags)
 	}
 	variables = append(variables, cliVars)
 
-	runner, err := tflint.NewRunner(cli.originalWorkingDir, cli.config, annotations, configs, variables...)
+	runner, err := tflint.NewRunner(loader.ContextMeta(), config, annotations, configs, variables...)
 	if err != nil {
 		return nil, []*tflint.Runner{}, fmt.Errorf("Failed to initialize a runner; %w", err)
 	}
@@ -276,50 +439,72 @@ func (cli *CLI) setupRunners(opts Options, dir string) (*tflint.Runner, []*tflin
 	return runner, moduleRunners, nil
 }
 
-func launchPlugins(config *tflint.Config, fix bool) (*plugin.Plugin, error) {
+// launchPlugins discovers and launches plugins, and checks that their versions are compatible.
+func launchPlugins(config *tflint.Config) (*plugin.Plugin, map[string]*version.Version, error) {
 	// Lookup plugins
 	rulesetPlugin, err := plugin.Discovery(config)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to initialize plugins; %w", err)
+		return nil, nil, fmt.Errorf("Failed to initialize plugins; %w", err)
 	}
 
-	rulesets := []tflint.RuleSet{}
-	pluginConf := config.ToPluginConfig()
-	pluginConf.Fix = fix
-
-	// Check version constraints and apply a config to plugins
+	// Check version constraints
+	sdkVersions := map[string]*version.Version{}
 	for name, ruleset := range rulesetPlugin.RuleSets {
 		constraints, err := ruleset.VersionConstraints()
 		if err != nil {
 			if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
 				// VersionConstraints endpoint is available in tflint-plugin-sdk v0.14+.
-				return rulesetPlugin, fmt.Errorf(`Plugin "%s" SDK version is incompatible. Compatible versions: %s`, name, plugin.SDKVersionConstraints)
+				return rulesetPlugin, nil, fmt.Errorf(`Plugin "%s" SDK version is incompatible. Compatible versions: %s`, name, plugin.SDKVersionConstraints)
 			} else {
-				return rulesetPlugin, fmt.Errorf(`Failed to get TofuLint version constraints to "%s" plugin; %w`, name, err)
+				return rulesetPlugin, nil, fmt.Errorf(`Failed to get TofuLint version constraints to "%s" plugin; %w`, name, err)
 			}
 		}
 		if !constraints.Check(tflint.Version) {
-			return rulesetPlugin, fmt.Errorf("Failed to satisfy version constraints; tflint-ruleset-%s requires %s, but TofuLint version is %s", name, constraints, tflint.Version)
+			return rulesetPlugin, nil, fmt.Errorf("Failed to satisfy version constraints; tflint-ruleset-%s requires %s, but TofuLint version is %s", name, constraints, tflint.Version)
+		}
+
+		sdkVersion, err := ruleset.SDKVersion()
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
+				// SDKVersion endpoint is available in tflint-plugin-sdk v0.14+.
+				return rulesetPlugin, nil, fmt.Errorf(`Plugin "%s" SDK version is incompatible. Compatible versions: %s`, name, plugin.SDKVersionConstraints)
+			} else {
+				return rulesetPlugin, nil, fmt.Errorf(`Failed to get plugin "%s" SDK version; %w`, name, err)
+			}
 		}
+		if !plugin.SDKVersionConstraints.Check(sdkVersion) {
+			return rulesetPlugin, nil, fmt.Errorf(`Plugin "%s" SDK version (%s) is incompatible. Compatible versions: %s`, name, sdkVersion, plugin.SDKVersionConstraints)
+		}
+		sdkVersions[name] = sdkVersion
+	}
 
+	return rulesetPlugin, sdkVersions, nil
+}
+
+// applyPluginConfig applies the config to launched plugins and validates rules.
+func applyPluginConfig(rulesetPlugin *plugin.Plugin, config *tflint.Config) error {
+	rulesets := []tflint.RuleSet{}
+	pluginConf := config.ToPluginConfig()
+
+	for name, ruleset := range rulesetPlugin.RuleSets {
 		if err := ruleset.ApplyGlobalConfig(pluginConf); err != nil {
-			return rulesetPlugin, fmt.Errorf(`Failed to apply global config to "%s" plugin; %w`, name, err)
+			return fmt.Errorf(`Failed to apply global config to "%s" plugin; %w`, name, err)
 		}
 		configSchema, err := ruleset.ConfigSchema()
 		if err != nil {
-			return rulesetPlugin, fmt.Errorf(`Failed to fetch config schema from "%s" plugin; %w`, name, err)
+			return fmt.Errorf(`Failed to fetch config schema from "%s" plugin; %w`, name, err)
 		}
 		content := &hclext.BodyContent{}
 		if plugin, exists := config.Plugins[name]; exists {
 			var diags hcl.Diagnostics
 			content, diags = plugin.Content(configSchema)
 			if diags.HasErrors() {
-				return rulesetPlugin, fmt.Errorf(`Failed to parse "%s" plugin config; %w`, name, diags)
+				return fmt.Errorf(`Failed to parse "%s" plugin config; %w`, name, diags)
 			}
 		}
 		err = ruleset.ApplyConfig(content, config.Sources())
 		if err != nil {
-			return rulesetPlugin, fmt.Errorf(`Failed to apply config to "%s" plugin; %w`, name, err)
+			return fmt.Errorf(`Failed to apply config to "%s" plugin; %w`, name, err)
 		}
 
 		rulesets = append(rulesets, ruleset)
@@ -327,10 +512,10 @@ func launchPlugins(config *tflint.Config, fix bool) (*plugin.Plugin, error) {
 
 	// Validate config for plugins
 	if err := config.ValidateRules(rulesets...); err != nil {
-		return rulesetPlugin, fmt.Errorf("Failed to check rule config; %w", err)
+		return fmt.Errorf("Failed to check rule config; %w", err)
 	}
 
-	return rulesetPlugin, nil
+	return nil
 }
 
 func writeChanges(changes map[string][]byte) error {
@@ -10,31 +10,40 @@ import (
 
 // Options is an option specified by arguments.
 type Options struct {
-	Version                bool     `short:"v" long:"version" description:"Print TofuLint version"`
-	Init                   bool     `long:"init" description:"Install plugins"`
-	Langserver             bool     `long:"langserver" description:"Start language server"`
-	Format                 string   `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif"`
-	Config                 string   `short:"c" long:"config" description:"Config file name (default: .tflint.hcl)" value-name:"FILE"`
-	IgnoreModules          []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
-	EnableRules            []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
-	DisableRules           []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
-	Only                   []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
-	EnablePlugins          []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
-	Varfiles               []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
-	Variables              []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
-	Module                 *bool    `long:"module" description:"Enable module inspection" hidden:"true"`
-	NoModule               *bool    `long:"no-module" description:"Disable module inspection" hidden:"true"`
-	CallModuleType         *string  `long:"call-module-type" description:"Types of module to call (default: local)" choice:"all" choice:"local" choice:"none"`
-	Chdir                  string   `long:"chdir" description:"Switch to a different working directory before executing the command" value-name:"DIR"`
-	Recursive              bool     `long:"recursive" description:"Run command in each directory recursively"`
-	Filter                 []string `long:"filter" description:"Filter issues by file names or globs" value-name:"FILE"`
-	Force                  *bool    `long:"force" description:"Return zero exit status even if issues found"`
-	MinimumFailureSeverity string   `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
-	Color                  bool     `long:"color" description:"Enable colorized output"`
-	NoColor                bool     `long:"no-color" description:"Disable colorized output"`
-	Fix                    bool     `long:"fix" description:"Fix issues automatically"`
-	NoParallelRunners      bool     `long:"no-parallel-runners" description:"Disable per-runner parallelism"`
-	ActAsBundledPlugin     bool     `long:"act-as-bundled-plugin" hidden:"true"`
+	Version                 bool     `short:"v" long:"version" description:"Print TofuLint version"`
+	Init                    bool     `long:"init" description:"Install plugins"`
+	Langserver              bool     `long:"langserver" description:"Start language server"`
+	ValidateConfig          bool     `long:"validate-config" description:"Validate the config and plugin settings without inspecting code"`
+	Format                  []string `short:"f" long:"format" description:"Output format (default, json, checkstyle, junit, compact, sarif, markdown, github, gitlab). Can be specified multiple times, and FORMAT:FILE writes the output to the file" value-name:"FORMAT[:FILE]"`
+	Config                  string   `short:"c" long:"config" description:"Config file name (default: .tflint.hcl)" value-name:"FILE"`
+	IgnoreModules           []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
+	EnableRules             []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
+	DisableRules            []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
+	Only                    []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
+	EnablePlugins           []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
+	Varfiles                []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
+	Variables               []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
+	Module                  *bool    `long:"module" description:"Enable module inspection" hidden:"true"`
+	NoModule                *bool    `long:"no-module" description:"Disable module inspection" hidden:"true"`
+	CallModuleType          *string  `long:"call-module-type" description:"Types of module to call (default: local)" choice:"all" choice:"local" choice:"none"`
+	Chdir                   string   `long:"chdir" description:"Switch to a different working directory before executing the command" value-name:"DIR"`
+	Recursive               bool     `long:"recursive" description:"Run command in each directory recursively"`
+	Jobs                    int      `long:"jobs" description:"Number of working directories to inspect in parallel (default: number of CPUs)" value-name:"N"`
+	Filter                  []string `long:"filter" description:"Filter issues by file names or globs" value-name:"FILE"`
+	DiffBase                string   `long:"diff-base" description:"Only report issues on lines changed since the git revision" value-name:"REVISION"`
+	Baseline                string   `long:"baseline" description:"Only report issues that are not recorded in the baseline file" value-name:"FILE"`
+	WriteBaseline           bool     `long:"write-baseline" description:"Record the current issues to the baseline file"`
+	Force                   *bool    `long:"force" description:"Return zero exit status even if issues found"`
+	MinimumFailureSeverity  string   `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
+	Color                   bool     `long:"color" description:"Enable colorized output"`
+	NoColor                 bool     `long:"no-color" description:"Disable colorized output"`
+	Fix                     bool     `long:"fix" description:"Fix issues automatically"`
+	DryRun                  bool     `long:"dry-run" description:"Show changes by --fix as a unified diff without applying them"`
+	FixRules                []string `long:"fix-rule" description:"Restrict --fix to this rule. Can be specified multiple times" value-name:"RULE_NAME"`
+	ReportUnusedAnnotations bool     `long:"report-unused-annotations" description:"Report annotations that do not ignore any issues or refer to unknown rules"`
+	IncludeSuppressed       bool     `long:"include-suppressed" description:"Include issues ignored by annotations in JSON and SARIF output"`
+	NoParallelRunners       bool     `long:"no-parallel-runners" description:"Disable per-runner parallelism"`
+	ActAsBundledPlugin      bool     `long:"act-as-bundled-plugin" hidden:"true"`
 }
 
 func (opts *Options) toConfig() *tflint.Config {
@@ -85,12 +94,14 @@ func (opts *Options) toConfig() *tflint.Config {
 	log.Printf("[DEBUG] CLI Options")
 	log.Printf("[DEBUG]   CallModuleType: %s", callModuleType)
 	log.Printf("[DEBUG]   Force: %t", force)
-	log.Printf("[DEBUG]   Format: %s", opts.Format)
+	log.Printf("[DEBUG]   Format: %s", strings.Join(opts.Format, ", "))
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(opts.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(opts.Variables, ", "))
 	log.Printf("[DEBUG]   EnableRules: %s", strings.Join(opts.EnableRules, ", "))
 	log.Printf("[DEBUG]   DisableRules: %s", strings.Join(opts.DisableRules, ", "))
 	log.Printf("[DEBUG]   Only: %s", strings.Join(opts.Only, ", "))
+	log.Printf("[DEBUG]   Fix: %t", opts.Fix)
+	log.Printf("[DEBUG]   FixRules: %s", strings.Join(opts.FixRules, ", "))
 	log.Printf("[DEBUG]   EnablePlugins: %s", strings.Join(opts.EnablePlugins, ", "))
 	log.Printf("[DEBUG]   IgnoreModules:")
 	for name, ignore := range ignoreModules {
@@ -133,8 +144,8 @@ func (opts *Options) toConfig() *tflint.Config {
 		Force:    force,
 		ForceSet: forceSet,
 
-		Format:    opts.Format,
-		FormatSet: opts.Format != "",
+		Formats:   opts.Format,
+		FormatSet: len(opts.Format) > 0,
 
 		DisabledByDefault:    len(opts.Only) > 0,
 		DisabledByDefaultSet: len(opts.Only) > 0,
@@ -143,6 +154,8 @@ func (opts *Options) toConfig() *tflint.Config {
 		Variables:     opts.Variables,
 		Only:          opts.Only,
 		IgnoreModules: ignoreModules,
+		Fix:           opts.Fix,
+		FixRules:      opts.FixRules,
 		Rules:         rules,
 		Plugins:       plugins,
 	}
@@ -274,7 +274,7 @@ func Test_toConfig(t *testing.T) {
 				Varfiles:          []string{},
 				Variables:         []string{},
 				DisabledByDefault: false,
-				Format:            "compact",
+				Formats:           []string{"compact"},
 				FormatSet:         true,
 				Rules:             map[string]*tflint.RuleConfig{},
 				Plugins:           map[string]*tflint.PluginConfig{},
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arsiba/tofulint/plugin"
+	"github.com/arsiba/tofulint/tflint"
+	"github.com/hashicorp/go-version"
+)
+
+// pluginPool keeps launched plugin processes and shares them between working directories.
+//
+// Plugins are launched once per set of plugin settings (plugin directory, names, versions, etc.),
+// and reused by any directory that requires the same plugins. The config is applied to plugins
+// only when it differs from the config applied last time, so directories that share the same
+// effective config do not pay for it again.
+//
+// Each set of plugins is used by one directory at a time, since the applied config is the state of
+// plugin processes. Up to maxIdle sets are kept after use, and the rest are killed.
+type pluginPool struct {
+	maxIdle int
+
+	mu   sync.Mutex
+	idle []*pooledPlugin
+}
+
+// pooledPlugin is a set of launched plugins managed by pluginPool.
+type pooledPlugin struct {
+	plugin      *plugin.Plugin
+	sdkVersions map[string]*version.Version
+
+	launchKey  string
+	configHash string
+}
+
+// acquire returns plugins with the passed config applied.
+// Idle plugins with the same config are preferred, then idle plugins that can be reconfigured.
+// If there are no such plugins, new plugin processes are launched.
+// The returned plugins must be released after use.
+func (p *pluginPool) acquire(config *tflint.Config) (*pooledPlugin, error) {
+	launchKey := pluginLaunchKey(config)
+	configHash, err := pluginConfigHash(config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize plugins; %w", err)
+	}
+
+	pooled := p.take(launchKey, configHash)
+	if pooled == nil {
+		rulesetPlugin, sdkVersions, err := launchPlugins(config)
+		if err != nil {
+			if rulesetPlugin != nil {
+				rulesetPlugin.Clean()
+			}
+			return nil, err
+		}
+		pooled = &pooledPlugin{
+			plugin:      rulesetPlugin,
+			sdkVersions: sdkVersions,
+			launchKey:   launchKey,
+		}
+	}
+
+	if pooled.configHash == configHash {
+		log.Print("[INFO] Reuse plugins with the same config")
+		return pooled, nil
+	}
+	// Clear the hash first, as the config may be partially applied on errors
+	pooled.configHash = ""
+	if err := applyPluginConfig(pooled.plugin, config); err != nil {
+		p.release(pooled)
+		return nil, err
+	}
+	pooled.configHash = configHash
+
+	return pooled, nil
+}
+
+// release returns the plugins to the pool.
+func (p *pluginPool) release(pooled *pooledPlugin) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.idle = append(p.idle, pooled)
+	for len(p.idle) > p.maxIdle {
+		p.idle[0].plugin.Clean()
+		p.idle = p.idle[1:]
+	}
+}
+
+// clean kills all idle plugin processes.
+func (p *pluginPool) clean() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pooled := range p.idle {
+		pooled.plugin.Clean()
+	}
+	p.idle = nil
+}
+
+func (p *pluginPool) take(launchKey string, configHash string) *pooledPlugin {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	found := -1
+	for i, pooled := range p.idle {
+		if pooled.launchKey != launchKey {
+			continue
+		}
+		found = i
+		if pooled.configHash == configHash {
+			break
+		}
+	}
+	if found < 0 {
+		return nil
+	}
+
+	pooled := p.idle[found]
+	p.idle = append(p.idle[:found], p.idle[found+1:]...)
+	return pooled
+}
+
+// pluginLaunchKey returns a key that identifies the plugin processes launched for the config.
+// Plugin-specific settings are not included because they are applied after launch.
+func pluginLaunchKey(config *tflint.Config) string {
+	names := make([]string, 0, len(config.Plugins))
+	for name := range config.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(config.PluginDir)
+	for _, name := range names {
+		p := config.Plugins[name]
+		fmt.Fprintf(&b, "\x00%s\x00%t\x00%s\x00%s\x00%s", p.Name, p.Enabled, p.Version, p.Source, p.SigningKey)
+	}
+	return b.String()
+}
+
+// pluginConfigHash returns a hash of the config applied to plugins.
+// In addition to the global config, it covers config files, since
+// plugin-specific settings are read from their sources.
+func pluginConfigHash(config *tflint.Config) (string, error) {
+	pluginConf := config.ToPluginConfig()
+
+	h := sha256.New()
+	if err := json.NewEncoder(h).Encode(pluginConf); err != nil {
+		return "", err
+	}
+
+	sources := config.Sources()
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(sources[name]))
+		h.Write(sources[name])
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
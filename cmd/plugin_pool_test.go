@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/arsiba/tofulint/plugin"
+	"github.com/arsiba/tofulint/tflint"
+	"github.com/spf13/afero"
+)
+
+func Test_pluginConfigHash(t *testing.T) {
+	loadConfig := func(src string) *tflint.Config {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		if err := fs.WriteFile(".tflint.hcl", []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		config, err := tflint.LoadConfig(fs, ".tflint.hcl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+
+	base := `
+rule "foo" {
+  enabled = true
+}
+plugin "foo" {
+  enabled = true
+}`
+
+	tests := []struct {
+		name string
+		src  string
+		fix  bool
+		want bool
+	}{
+		{
+			name: "same config",
+			src:  base,
+			want: true,
+		},
+		{
+			name: "fix",
+			src:  base,
+			fix:  true,
+			want: false,
+		},
+		{
+			name: "different rule config",
+			src: `
+rule "foo" {
+  enabled = false
+}
+plugin "foo" {
+  enabled = true
+}`,
+			want: false,
+		},
+		{
+			name: "different plugin config",
+			src: `
+rule "foo" {
+  enabled = true
+}
+plugin "foo" {
+  enabled = true
+  preset  = "all"
+}`,
+			want: false,
+		},
+	}
+
+	want, err := pluginConfigHash(loadConfig(base))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := loadConfig(test.src)
+			config.Fix = test.fix
+
+			got, err := pluginConfigHash(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == want) != test.want {
+				t.Errorf("got %s, want equal=%t", got, test.want)
+			}
+			if pluginLaunchKey(config) != pluginLaunchKey(loadConfig(base)) {
+				t.Errorf("launch key should not depend on the plugin config")
+			}
+		})
+	}
+}
+
+func Test_pluginPool_take(t *testing.T) {
+	newPooled := func(launchKey string, configHash string) *pooledPlugin {
+		return &pooledPlugin{plugin: &plugin.Plugin{}, launchKey: launchKey, configHash: configHash}
+	}
+	other := newPooled("other", "foo")
+	reconfigurable := newPooled("key", "bar")
+	sameConfig := newPooled("key", "foo")
+
+	pool := &pluginPool{maxIdle: 3}
+	pool.release(other)
+	pool.release(reconfigurable)
+	pool.release(sameConfig)
+
+	if got := pool.take("key", "foo"); got != sameConfig {
+		t.Errorf("expected plugins with the same config, got %#v", got)
+	}
+	if got := pool.take("key", "foo"); got != reconfigurable {
+		t.Errorf("expected plugins with the same launch key, got %#v", got)
+	}
+	if got := pool.take("key", "foo"); got != nil {
+		t.Errorf("expected no plugins, got %#v", got)
+	}
+
+	pool.release(reconfigurable)
+	pool.release(sameConfig)
+	pool.release(newPooled("key", "baz"))
+	if len(pool.idle) != 3 {
+		t.Fatalf("expected 3 idle plugins, got %d", len(pool.idle))
+	}
+	if pool.idle[0] != reconfigurable {
+		t.Errorf("the oldest idle plugins should be cleaned up")
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/arsiba/tofulint/opentofu"
+	"github.com/arsiba/tofulint/plugin"
+	"github.com/arsiba/tofulint/tflint"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func (cli *CLI) validateConfig(opts Options) int {
+	// The result is printed as a plain message per working directory, so other formats and output files are not supported
+	for _, output := range cli.formatter.Outputs {
+		if output.Format != "default" || output.Path != "" {
+			cli.formatter.Outputs = nil
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("--validate-config supports only the default format"), map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+
+	workingDirs, err := findWorkingDirs(opts)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to find workspaces; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	exitCode := ExitCodeOK
+	for _, wd := range workingDirs {
+		if opts.Recursive {
+			fmt.Fprint(cli.outStream, "====================================================\n")
+			fmt.Fprintf(cli.outStream, "working directory: %s\n\n", wd)
+		}
+
+		sources, err := cli.validateWorkingDirConfig(opts, wd)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, sources)
+			exitCode = ExitCodeError
+			continue
+		}
+		fmt.Fprint(cli.outStream, "The config is valid\n")
+	}
+
+	return exitCode
+}
+
+// validateWorkingDirConfig loads the config in the working directory and validates it.
+// Like inspectWorkingDir, paths are resolved against the working directory without os.Chdir.
+// It returns the config sources to print errors.
+func (cli *CLI) validateWorkingDirConfig(opts Options, wd string) (map[string][]byte, error) {
+	fs := afero.Afero{Fs: opentofu.NewWorkingDirFs(afero.NewOsFs(), wd)}
+	cfg, err := tflint.LoadConfig(fs, opts.Config)
+	if err != nil {
+		return map[string][]byte{}, fmt.Errorf("Failed to load TofuLint config; %w", err)
+	}
+	cfg.Merge(opts.toConfig())
+	sources := cfg.Sources()
+
+	absWd := wd
+	if !filepath.IsAbs(absWd) {
+		absWd = filepath.Join(cli.originalWorkingDir, wd)
+	}
+	if err := plugin.ResolvePluginDir(cfg, absWd); err != nil {
+		return sources, fmt.Errorf("Failed to initialize plugins; %w", err)
+	}
+
+	diags, err := validatePluginConfig(cfg)
+	if err != nil {
+		return sources, err
+	}
+	if diags.HasErrors() {
+		return sources, fmt.Errorf("Invalid TofuLint config; %w", diags)
+	}
+	return sources, nil
+}
+
+// validatePluginConfig launches plugins and validates the config against them.
+// Unlike applyPluginConfig, it does not stop at the first invalid plugin or rule config,
+// and returns diagnostics for all of them. Errors are returned only if plugins cannot be used.
+func validatePluginConfig(config *tflint.Config) (hcl.Diagnostics, error) {
+	rulesetPlugin, _, err := launchPlugins(config)
+	if rulesetPlugin != nil {
+		defer rulesetPlugin.Clean()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rulesetPlugin.RuleSets))
+	for name := range rulesetPlugin.RuleSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	diags := hcl.Diagnostics{}
+	rulesets := []tflint.RuleSet{}
+	pluginConf := config.ToPluginConfig()
+
+	for _, name := range names {
+		ruleset := rulesetPlugin.RuleSets[name]
+		rulesets = append(rulesets, ruleset)
+
+		if err := ruleset.ApplyGlobalConfig(pluginConf); err != nil {
+			return diags, fmt.Errorf(`Failed to apply global config to "%s" plugin; %w`, name, err)
+		}
+		configSchema, err := ruleset.ConfigSchema()
+		if err != nil {
+			return diags, fmt.Errorf(`Failed to fetch config schema from "%s" plugin; %w`, name, err)
+		}
+		content := &hclext.BodyContent{}
+		if plugin, exists := config.Plugins[name]; exists {
+			var contentDiags hcl.Diagnostics
+			content, contentDiags = plugin.Content(configSchema)
+			if contentDiags.HasErrors() {
+				diags = diags.Extend(contentDiags)
+				continue
+			}
+		}
+		// Rule configs are decoded by plugins, so errors are reported as plain messages
+		if err := ruleset.ApplyConfig(content, config.Sources()); err != nil {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf(`Failed to apply config to "%s" plugin`, name),
+				Detail:   err.Error(),
+			})
+		}
+	}
+
+	ruleDiags, err := config.DiagnoseRules(rulesets...)
+	if err != nil {
+		return diags, fmt.Errorf("Failed to check rule config; %w", err)
+	}
+	return diags.Extend(ruleDiags), nil
+}
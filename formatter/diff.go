@@ -0,0 +1,121 @@
+package formatter
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContextLines is the number of unchanged lines around changes in a unified diff.
+const diffContextLines = 3
+
+// diffLine is a line in a line-based diff. Text includes the trailing newline if present.
+type diffLine struct {
+	Type diffmatchpatch.Operation
+	Text string
+}
+
+// unifiedDiff returns a unified diff of changed sources against the original sources.
+// Files are sorted by name, and the paths are prefixed with "a/" and "b/" like git.
+func unifiedDiff(changes map[string][]byte, sources map[string][]byte) string {
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		b.WriteString(unifiedFileDiff(filepath.ToSlash(path), sources[path], changes[path]))
+	}
+	return b.String()
+}
+
+// unifiedFileDiff returns a unified diff of a file. It returns an empty string if there are no changes.
+func unifiedFileDiff(path string, original []byte, fixed []byte) string {
+	lines := []diffLine{}
+	for _, d := range diff.Do(string(original), string(fixed)) {
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				lines = append(lines, diffLine{Type: d.Type, Text: text})
+			}
+		}
+	}
+
+	// Collect ranges of lines to print, merging changes with overlapping context
+	type hunkRange struct{ start, end int }
+	ranges := []hunkRange{}
+	for i, line := range lines {
+		if line.Type == diffmatchpatch.DiffEqual {
+			continue
+		}
+		start := max(i-diffContextLines, 0)
+		end := min(i+diffContextLines+1, len(lines))
+		if len(ranges) > 0 && start <= ranges[len(ranges)-1].end {
+			ranges[len(ranges)-1].end = end
+		} else {
+			ranges = append(ranges, hunkRange{start: start, end: end})
+		}
+	}
+	if len(ranges) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+
+	oldLine, newLine := 1, 1
+	pos := 0
+	for _, r := range ranges {
+		// Skip unchanged lines before the hunk
+		for ; pos < r.start; pos++ {
+			oldLine++
+			newLine++
+		}
+
+		var oldCount, newCount int
+		var body strings.Builder
+		for _, line := range lines[r.start:r.end] {
+			switch line.Type {
+			case diffmatchpatch.DiffEqual:
+				body.WriteString(" ")
+				oldCount++
+				newCount++
+			case diffmatchpatch.DiffDelete:
+				body.WriteString("-")
+				oldCount++
+			case diffmatchpatch.DiffInsert:
+				body.WriteString("+")
+				newCount++
+			}
+			body.WriteString(line.Text)
+			if !strings.HasSuffix(line.Text, "\n") {
+				body.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkPos(oldLine, oldCount), hunkPos(newLine, newCount))
+		b.WriteString(body.String())
+
+		oldLine += oldCount
+		newLine += newCount
+		pos = r.end
+	}
+
+	return b.String()
+}
+
+// hunkPos formats the position of a hunk. An empty range refers to the line before it.
+func hunkPos(line int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
@@ -0,0 +1,118 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_unifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes map[string][]byte
+		sources map[string][]byte
+		want    string
+	}{
+		{
+			name:    "no changes",
+			changes: map[string][]byte{"main.tf": []byte("a\nb\n")},
+			sources: map[string][]byte{"main.tf": []byte("a\nb\n")},
+			want:    "",
+		},
+		{
+			name:    "single line",
+			changes: map[string][]byte{"main.tf": []byte("# foo\n")},
+			sources: map[string][]byte{"main.tf": []byte("// foo\n")},
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1 +1 @@
+-// foo
++# foo
+`,
+		},
+		{
+			name: "context and multiple hunks",
+			changes: map[string][]byte{
+				"main.tf": []byte("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\nTWELVE\n"),
+			},
+			sources: map[string][]byte{
+				"main.tf": []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"),
+			},
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++TWELVE
+`,
+		},
+		{
+			name:    "insertion and deletion",
+			changes: map[string][]byte{"main.tf": []byte("a\nb\nc\n")},
+			sources: map[string][]byte{"main.tf": []byte("a\nc\nd\n")},
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,3 +1,3 @@
+ a
++b
+ c
+-d
+`,
+		},
+		{
+			name:    "no newline at end of file",
+			changes: map[string][]byte{"main.tf": []byte("a\nB")},
+			sources: map[string][]byte{"main.tf": []byte("a\nb")},
+			want: `--- a/main.tf
++++ b/main.tf
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++B
+\ No newline at end of file
+`,
+		},
+		{
+			name: "multiple files",
+			changes: map[string][]byte{
+				"b.tf": []byte("B\n"),
+				"a.tf": []byte("A\n"),
+			},
+			sources: map[string][]byte{
+				"a.tf": []byte("a\n"),
+				"b.tf": []byte("b\n"),
+			},
+			want: `--- a/a.tf
++++ b/a.tf
+@@ -1 +1 @@
+-a
++A
+--- a/b.tf
++++ b/b.tf
+@@ -1 +1 @@
+-b
++B
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := unifiedDiff(test.changes, test.sources)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package formatter
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"os"
 
 	"github.com/arsiba/tofulint/tflint"
 	hcl "github.com/hashicorp/hcl/v2"
@@ -16,26 +20,151 @@ type Formatter struct {
 	Format  string
 	Fix     bool
 	NoColor bool
+
+	// FixRules restricts autofixes to these rules. If empty, all fixable issues are fixed.
+	FixRules []string
+
+	// Changes are the sources rewritten by autofixes, keyed by filename.
+	// They are used to describe fixes in formats that support them.
+	Changes map[string][]byte
+	// Diff outputs Changes as a unified diff against the original sources.
+	// It is used when changes are previewed instead of applied.
+	Diff bool
+
+	// Suppressed are issues ignored by annotations.
+	// They are only output in formats that can mark issues as suppressed.
+	Suppressed tflint.Issues
+
+	// Outputs are destinations of results. If set, they take precedence over Format,
+	// and results are written in each format. Outputs without a path are written to stdout.
+	Outputs []Output
+
+	// Results printed so far are accumulated per file, and each file is rewritten
+	// as a whole so that it always contains a single document.
+	results map[string]*fileResult
+}
+
+type fileResult struct {
+	issues  tflint.Issues
+	errs    []error
+	sources map[string][]byte
+}
+
+// Output is a destination of results in a format.
+type Output struct {
+	Format string
+	Path   string
+}
+
+// ParseOutputs parses formats like "sarif:results.sarif" into outputs.
+func ParseOutputs(formats []string) ([]Output, error) {
+	outputs := make([]Output, 0, len(formats))
+	for _, format := range formats {
+		name, path, err := tflint.ParseFormat(format)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, Output{Format: name, Path: path})
+	}
+	return outputs, nil
 }
 
 // Print outputs the given issues and errors according to configured format
 func (f *Formatter) Print(issues tflint.Issues, err error, sources map[string][]byte) {
-	switch f.Format {
+	if len(f.Outputs) == 0 {
+		f.print(f.Format, issues, err, sources)
+		return
+	}
+
+	for _, output := range f.Outputs {
+		if output.Path == "" {
+			f.print(output.Format, issues, err, sources)
+			continue
+		}
+		if writeErr := f.printToFile(output, issues, err, sources); writeErr != nil {
+			fmt.Fprintf(f.Stderr, "Failed to write %s output to %s; %s\n", output.Format, output.Path, writeErr)
+		}
+	}
+}
+
+// printToFile writes results to the file of the output.
+// Errors are also written to the file instead of stderr, and colors are disabled.
+// Results of previous calls are included, so the file is overwritten with all results printed so far.
+func (f *Formatter) printToFile(output Output, issues tflint.Issues, err error, sources map[string][]byte) error {
+	if f.results == nil {
+		f.results = map[string]*fileResult{}
+	}
+	result, exists := f.results[output.Path]
+	if !exists {
+		result = &fileResult{issues: tflint.Issues{}, sources: map[string][]byte{}}
+		f.results[output.Path] = result
+	}
+	result.issues = append(result.issues, issues...)
+	if err != nil {
+		result.errs = append(result.errs, err)
+	}
+	maps.Copy(result.sources, sources)
+
+	var buf bytes.Buffer
+	dest := &Formatter{Stdout: &buf, Stderr: &buf, Fix: f.Fix, FixRules: f.FixRules, Changes: f.Changes, Diff: f.Diff, Suppressed: f.Suppressed, NoColor: true}
+	dest.print(output.Format, result.issues, errors.Join(result.errs...), result.sources)
+
+	return os.WriteFile(output.Path, buf.Bytes(), 0644)
+}
+
+func (f *Formatter) print(format string, issues tflint.Issues, err error, sources map[string][]byte) {
+	switch format {
 	case "default":
 		f.prettyPrint(issues, err, sources)
+		f.printDiff(sources)
 	case "json":
-		f.jsonPrint(issues, err)
+		f.jsonPrint(issues, err, sources)
 	case "checkstyle":
 		f.checkstylePrint(issues, err, sources)
 	case "junit":
 		f.junitPrint(issues, err, sources)
 	case "compact":
 		f.compactPrint(issues, err, sources)
+		f.printDiff(sources)
 	case "sarif":
-		f.sarifPrint(issues, err)
+		f.sarifPrint(issues, err, sources)
+	case "markdown":
+		f.markdownPrint(issues, err, sources)
+	case "github":
+		f.githubPrint(issues, err, sources)
+	case "gitlab":
+		f.gitlabPrint(issues, err, sources)
 	default:
 		f.prettyPrint(issues, err, sources)
+		f.printDiff(sources)
+	}
+}
+
+// fixSelected returns true if autofixes by the rule of the issue are selected.
+// Whether they are applied depends on Fix.
+func (f *Formatter) fixSelected(issue *tflint.Issue) bool {
+	if len(f.FixRules) == 0 {
+		return true
+	}
+	for _, name := range f.FixRules {
+		if name == issue.Rule.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+// printDiff outputs changes by autofixes as a unified diff if Diff is enabled.
+func (f *Formatter) printDiff(sources map[string][]byte) {
+	if !f.Diff {
+		return
+	}
+	diff := unifiedDiff(f.Changes, sources)
+	if diff == "" {
+		return
 	}
+	fmt.Fprintf(f.Stdout, "%s\n\n", f.colorize(colorBold, "The following changes would be made by --fix:"))
+	fmt.Fprint(f.Stdout, diff)
 }
 
 func toSeverity(lintType tflint.Severity) string {
@@ -1,7 +1,15 @@
 package formatter
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
 	"github.com/arsiba/tofulint/tflint"
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
 	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
 )
 
@@ -22,3 +30,66 @@ func (r *testRule) Severity() tflint.Severity {
 func (r *testRule) Link() string {
 	return "https://github.com"
 }
+
+func Test_PrintOutputs(t *testing.T) {
+	dir := t.TempDir()
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		},
+	}
+
+	outputs, err := ParseOutputs([]string{"compact", "json:" + filepath.Join(dir, "results.json")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: stderr, Outputs: outputs}
+
+	formatter.Print(issues, nil, map[string][]byte{})
+	formatter.Print(tflint.Issues{}, errors.New("an error occurred"), map[string][]byte{})
+
+	wantStdout := "1 issue(s) found:\n\ntest.tf:1:1: Error - test (test_rule)\n"
+	if diff := cmp.Diff(wantStdout, stdout.String()); diff != "" {
+		t.Errorf("stdout: %s", diff)
+	}
+	if diff := cmp.Diff("an error occurred\n", stderr.String()); diff != "" {
+		t.Errorf("stderr: %s", diff)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "results.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file is rewritten with all results as a single document
+	want := `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[]}],"errors":[{"message":"an error occurred","severity":"error"}]}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("file: %s", diff)
+	}
+}
+
+func Test_ParseOutputs(t *testing.T) {
+	got, err := ParseOutputs([]string{"default", "sarif:results.sarif", "junit:C:\\report.xml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Output{
+		{Format: "default"},
+		{Format: "sarif", Path: "results.sarif"},
+		{Format: "junit", Path: "C:\\report.xml"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := ParseOutputs([]string{"invalid:results.txt"}); err == nil {
+		t.Error("expected an error, but got nil")
+	}
+}
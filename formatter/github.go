@@ -0,0 +1,53 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
+	"github.com/arsiba/tofulint/tflint"
+)
+
+// githubPrint outputs issues as GitHub Actions workflow commands.
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func (f *Formatter) githubPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	for _, issue := range issues.Sort() {
+		fmt.Fprintf(
+			f.Stdout,
+			"::%s file=%s,line=%d,col=%d,endLine=%d,endColumn=%d,title=%s::%s\n",
+			toGitHubCommand(issue.Rule.Severity()),
+			escapeGitHubProperty(issue.Range.Filename),
+			issue.Range.Start.Line,
+			issue.Range.Start.Column,
+			issue.Range.End.Line,
+			issue.Range.End.Column,
+			escapeGitHubProperty(issue.Rule.Name()),
+			escapeGitHubData(issue.Message),
+		)
+	}
+
+	if appErr != nil {
+		f.prettyPrintErrors(appErr, sources)
+	}
+}
+
+func toGitHubCommand(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "error"
+	case sdk.WARNING:
+		return "warning"
+	case sdk.NOTICE:
+		return "notice"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
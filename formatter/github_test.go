@@ -0,0 +1,62 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/arsiba/tofulint/tflint"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_githubPrint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+		Stderr string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test: 100%\nnext line",
+					Range: hcl.Range{
+						Filename: "dir,name/test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Stdout: "::error file=dir%2Cname/test.tf,line=1,col=1,endLine=2,endColumn=4,title=test_rule::test: 100%25%0Anext line\n",
+		},
+		{
+			Name:   "error",
+			Error:  errors.New("an error occurred"),
+			Stderr: "an error occurred\n",
+		},
+	}
+
+	for _, tc := range cases {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+		formatter.githubPrint(tc.Issues, tc.Error, map[string][]byte{})
+
+		if stdout.String() != tc.Stdout {
+			t.Errorf("Failed %s test: expected=%s, stdout=%s", tc.Name, tc.Stdout, stdout.String())
+		}
+
+		if stderr.String() != tc.Stderr {
+			t.Errorf("Failed %s test: expected=%s, stderr=%s", tc.Name, tc.Stderr, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package formatter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
+	"github.com/arsiba/tofulint/tflint"
+)
+
+// GitLabIssue is a temporary structure for converting TofuLint issues to GitLab Code Quality reports.
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+type GitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    GitLabLocation `json:"location"`
+}
+
+// GitLabLocation is a temporary structure for converting ranges to GitLab Code Quality reports.
+type GitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines GitLabLines `json:"lines"`
+}
+
+// GitLabLines is a temporary structure for converting lines to GitLab Code Quality reports.
+type GitLabLines struct {
+	Begin int `json:"begin"`
+}
+
+// gitlabPrint outputs issues as a GitLab Code Quality report.
+// Errors are not part of the report, so they are printed to stderr.
+func (f *Formatter) gitlabPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	ret := make([]GitLabIssue, len(issues))
+
+	// The fingerprint does not depend on line numbers, so that GitLab can compare reports
+	// between branches. Identical issues get a suffix to keep fingerprints unique.
+	seen := map[string]int{}
+	for idx, issue := range issues.Sort() {
+		withSource := *issue
+		if withSource.Source == nil {
+			withSource.Source = sources[issue.Range.Filename]
+		}
+		fingerprint := withSource.Fingerprint()
+		if count := seen[fingerprint]; count > 0 {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", fingerprint, count)))
+			seen[fingerprint]++
+			fingerprint = hex.EncodeToString(sum[:])
+		} else {
+			seen[fingerprint]++
+		}
+
+		ret[idx] = GitLabIssue{
+			Description: issue.Message,
+			CheckName:   issue.Rule.Name(),
+			Fingerprint: fingerprint,
+			Severity:    toGitLabSeverity(issue.Rule.Severity()),
+			Location: GitLabLocation{
+				Path:  filepath.ToSlash(issue.Range.Filename),
+				Lines: GitLabLines{Begin: issue.Range.Start.Line},
+			},
+		}
+	}
+
+	out, err := json.Marshal(ret)
+	if err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+	fmt.Fprint(f.Stdout, string(out))
+
+	if appErr != nil {
+		f.prettyPrintErrors(appErr, sources)
+	}
+}
+
+func toGitLabSeverity(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "major"
+	case sdk.WARNING:
+		return "minor"
+	case sdk.NOTICE:
+		return "info"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
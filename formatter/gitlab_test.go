@@ -0,0 +1,102 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/arsiba/tofulint/tflint"
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_gitlabPrint(t *testing.T) {
+	src := []byte(`resource "foo" "bar" {
+  baz = 1
+  baz = 1
+}
+`)
+	issueAt := func(line int) *tflint.Issue {
+		return &tflint.Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: line, Column: 3},
+				End:      hcl.Pos{Line: line, Column: 10},
+			},
+			Source: src,
+		}
+	}
+
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+		Stderr string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "[]",
+		},
+		{
+			Name:   "error",
+			Issues: tflint.Issues{},
+			Error:  errors.New("an error occurred"),
+			Stdout: "[]",
+			Stderr: "an error occurred\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.gitlabPrint(tc.Issues, tc.Error, map[string][]byte{})
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+
+	t.Run("issues", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+		formatter.gitlabPrint(tflint.Issues{issueAt(2), issueAt(3)}, nil, map[string][]byte{})
+
+		var got []GitLabIssue
+		if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("expected 2 issues, got %d", len(got))
+		}
+
+		want := GitLabIssue{
+			Description: "test",
+			CheckName:   "test_rule",
+			Fingerprint: issueAt(2).Fingerprint(),
+			Severity:    "major",
+			Location: GitLabLocation{
+				Path:  "test.tf",
+				Lines: GitLabLines{Begin: 2},
+			},
+		}
+		if diff := cmp.Diff(want, got[0]); diff != "" {
+			t.Error(diff)
+		}
+		if got[1].Fingerprint == got[0].Fingerprint {
+			t.Errorf("fingerprints of identical issues must be unique: %s", got[1].Fingerprint)
+		}
+	})
+}
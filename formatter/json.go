@@ -16,6 +16,8 @@ type JSONIssue struct {
 	Message string      `json:"message"`
 	Range   JSONRange   `json:"range"`
 	Callers []JSONRange `json:"callers"`
+	// Suppressed is true if the issue is ignored by an annotation. Such issues are only present with --include-suppressed.
+	Suppressed bool `json:"suppressed,omitempty"`
 }
 
 // JSONRule is a temporary structure for converting TofuLint rules to JSON.
@@ -50,12 +52,15 @@ type JSONError struct {
 type JSONOutput struct {
 	Issues []JSONIssue `json:"issues"`
 	Errors []JSONError `json:"errors"`
+	// Diff is a unified diff of changes by autofixes. It is only present when they are previewed.
+	Diff string `json:"diff,omitempty"`
 }
 
-func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
-	ret := &JSONOutput{Issues: make([]JSONIssue, len(issues)), Errors: []JSONError{}}
+func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	all := append(append(tflint.Issues{}, issues...), f.Suppressed...)
+	ret := &JSONOutput{Issues: make([]JSONIssue, len(all)), Errors: []JSONError{}}
 
-	for idx, issue := range issues.Sort() {
+	for idx, issue := range all.Sort() {
 		ret.Issues[idx] = JSONIssue{
 			Rule: JSONRule{
 				Name:     issue.Rule.Name(),
@@ -68,7 +73,8 @@ func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
 				Start:    JSONPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
 				End:      JSONPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
 			},
-			Callers: make([]JSONRange, len(issue.Callers)),
+			Callers:    make([]JSONRange, len(issue.Callers)),
+			Suppressed: issue.SuppressedBy != nil,
 		}
 		for i, caller := range issue.Callers {
 			ret.Issues[idx].Callers[i] = JSONRange{
@@ -103,6 +109,10 @@ func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
 		}
 	}
 
+	if f.Diff {
+		ret.Diff = unifiedDiff(f.Changes, sources)
+	}
+
 	out, err := json.Marshal(ret)
 	if err != nil {
 		fmt.Fprint(f.Stderr, err)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/arsiba/tofulint/tflint"
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 func Test_jsonPrint(t *testing.T) {
@@ -60,3 +61,54 @@ func Test_jsonPrint(t *testing.T) {
 		}
 	}
 }
+
+func Test_jsonPrint_diff(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+		Format:  "json",
+		Changes: map[string][]byte{"main.tf": []byte("# foo\n")},
+		Diff:    true,
+	}
+
+	formatter.Print(tflint.Issues{}, nil, map[string][]byte{"main.tf": []byte("// foo\n")})
+
+	want := `{"issues":[],"errors":[],"diff":"--- a/main.tf\n+++ b/main.tf\n@@ -1 +1 @@\n-// foo\n+# foo\n"}`
+	if stdout.String() != want {
+		t.Fatalf("expected=%s, stdout=%s", want, stdout.String())
+	}
+}
+
+func Test_jsonPrint_suppressed(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		Format: "json",
+		Suppressed: tflint.Issues{
+			{
+				Rule:    &testRule{},
+				Message: "suppressed",
+				Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 4}},
+				SuppressedBy: &tflint.LineAnnotation{
+					Content: "test_rule",
+					Token:   hclsyntax.Token{Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1}}},
+				},
+			},
+		},
+	}
+
+	formatter.Print(tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "reported",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 4}},
+		},
+	}, nil, map[string][]byte{})
+
+	want := `{"issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"reported","range":{"filename":"test.tf","start":{"line":1,"column":1},"end":{"line":1,"column":4}},"callers":[]},{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"suppressed","range":{"filename":"test.tf","start":{"line":2,"column":1},"end":{"line":2,"column":4}},"callers":[],"suppressed":true}],"errors":[]}`
+	if stdout.String() != want {
+		t.Fatalf("expected=%s, stdout=%s", want, stdout.String())
+	}
+}
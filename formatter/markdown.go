@@ -0,0 +1,113 @@
+package formatter
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arsiba/tofulint/tflint"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// markdownPrint outputs issues as a Markdown document suitable for a pull request comment.
+// It consists of a summary table by rule and severity, and a collapsible section per file.
+func (f *Formatter) markdownPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	if len(issues) > 0 {
+		fmt.Fprint(f.Stdout, "## TofuLint\n\n")
+		fmt.Fprintf(f.Stdout, "%d issue(s) found.\n\n", len(issues))
+
+		f.markdownPrintSummary(issues)
+
+		issues = issues.Sort()
+		for start := 0; start < len(issues); {
+			end := start + 1
+			for end < len(issues) && issues[end].Range.Filename == issues[start].Range.Filename {
+				end++
+			}
+			f.markdownPrintFile(issues[start:end], sources)
+			start = end
+		}
+	}
+
+	if appErr != nil {
+		f.prettyPrintErrors(appErr, sources)
+	}
+}
+
+func (f *Formatter) markdownPrintSummary(issues tflint.Issues) {
+	type summaryKey struct {
+		rule     string
+		severity tflint.Severity
+	}
+	counts := map[summaryKey]int{}
+	keys := []summaryKey{}
+	for _, issue := range issues {
+		key := summaryKey{rule: issue.Rule.Name(), severity: issue.Rule.Severity()}
+		if _, exists := counts[key]; !exists {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].rule != keys[j].rule {
+			return keys[i].rule < keys[j].rule
+		}
+		return keys[i].severity < keys[j].severity
+	})
+
+	fmt.Fprint(f.Stdout, "| Rule | Severity | Count |\n")
+	fmt.Fprint(f.Stdout, "| --- | --- | ---: |\n")
+	for _, key := range keys {
+		fmt.Fprintf(f.Stdout, "| `%s` | %s | %d |\n", key.rule, key.severity, counts[key])
+	}
+	fmt.Fprint(f.Stdout, "\n")
+}
+
+func (f *Formatter) markdownPrintFile(issues tflint.Issues, sources map[string][]byte) {
+	fmt.Fprint(f.Stdout, "<details>\n")
+	fmt.Fprintf(f.Stdout, "<summary><code>%s</code> (%d issue(s))</summary>\n\n", markdownEscapeHTML(issues[0].Range.Filename), len(issues))
+
+	for _, issue := range issues {
+		message := issue.Message
+		if issue.Fixable {
+			if f.Fix && f.fixSelected(issue) {
+				message = "[Fixed] " + message
+			} else {
+				message = "[Fixable] " + message
+			}
+		}
+
+		rule := fmt.Sprintf("`%s`", issue.Rule.Name())
+		if issue.Rule.Link() != "" {
+			rule = fmt.Sprintf("[%s](%s)", rule, issue.Rule.Link())
+		}
+		fmt.Fprintf(f.Stdout, "**%s**: %s (%s)\n\n", issue.Rule.Severity(), markdownEscapeHTML(message), rule)
+		fmt.Fprintf(f.Stdout, "on line %d:\n\n", issue.Range.Start.Line)
+
+		src := issue.Source
+		if src == nil {
+			src = sources[issue.Range.Filename]
+		}
+		if src == nil {
+			fmt.Fprint(f.Stdout, "(source code not available)\n\n")
+			continue
+		}
+
+		fmt.Fprint(f.Stdout, "```hcl\n")
+		sc := hcl.NewRangeScanner(src, issue.Range.Filename, bufio.ScanLines)
+		for sc.Scan() {
+			if !sc.Range().Overlaps(issue.Range) {
+				continue
+			}
+			fmt.Fprintf(f.Stdout, "%4d: %s\n", sc.Range().Start.Line, sc.Bytes())
+		}
+		fmt.Fprint(f.Stdout, "```\n\n")
+	}
+
+	fmt.Fprint(f.Stdout, "</details>\n\n")
+}
+
+func markdownEscapeHTML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
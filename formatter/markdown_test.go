@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/arsiba/tofulint/tflint"
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_markdownPrint(t *testing.T) {
+	src := []byte(`resource "foo" "bar" {
+  baz = "<qux>"
+}
+`)
+
+	cases := []struct {
+		Name    string
+		Issues  tflint.Issues
+		Sources map[string][]byte
+		Error   error
+		Stdout  string
+		Stderr  string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test <qux>",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 25},
+						End:      hcl.Pos{Line: 2, Column: 16, Byte: 38},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "other.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Sources: map[string][]byte{"test.tf": src},
+			Stdout: "## TofuLint\n\n" +
+				"2 issue(s) found.\n\n" +
+				"| Rule | Severity | Count |\n" +
+				"| --- | --- | ---: |\n" +
+				"| `test_rule` | Error | 2 |\n\n" +
+				"<details>\n" +
+				"<summary><code>other.tf</code> (1 issue(s))</summary>\n\n" +
+				"**Error**: test ([`test_rule`](https://github.com))\n\n" +
+				"on line 1:\n\n" +
+				"(source code not available)\n\n" +
+				"</details>\n\n" +
+				"<details>\n" +
+				"<summary><code>test.tf</code> (1 issue(s))</summary>\n\n" +
+				"**Error**: test &lt;qux&gt; ([`test_rule`](https://github.com))\n\n" +
+				"on line 2:\n\n" +
+				"```hcl\n" +
+				"   2:   baz = \"<qux>\"\n" +
+				"```\n\n" +
+				"</details>\n\n",
+		},
+		{
+			Name:   "error",
+			Error:  errors.New("an error occurred"),
+			Stderr: "an error occurred\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr}
+
+			formatter.markdownPrint(tc.Issues, tc.Error, tc.Sources)
+
+			if diff := cmp.Diff(tc.Stdout, stdout.String()); diff != "" {
+				t.Errorf("stdout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.Stderr, stderr.String()); diff != "" {
+				t.Errorf("stderr: %s", diff)
+			}
+		})
+	}
+}
@@ -14,11 +14,11 @@ import (
 	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
 )
 
-var colorBold = color.New(color.Bold).SprintfFunc()
-var colorHighlight = color.New(color.Bold).Add(color.Underline).SprintFunc()
-var colorError = color.New(color.FgRed).SprintFunc()
-var colorWarning = color.New(color.FgYellow).SprintFunc()
-var colorNotice = color.New(color.FgHiWhite).SprintFunc()
+var colorBold = color.New(color.Bold)
+var colorHighlight = color.New(color.Bold).Add(color.Underline)
+var colorError = color.New(color.FgRed)
+var colorWarning = color.New(color.FgYellow)
+var colorNotice = color.New(color.FgHiWhite)
 
 func (f *Formatter) prettyPrint(issues tflint.Issues, err error, sources map[string][]byte) {
 	if len(issues) > 0 {
@@ -37,7 +37,7 @@ func (f *Formatter) prettyPrint(issues tflint.Issues, err error, sources map[str
 func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[string][]byte) {
 	message := issue.Message
 	if issue.Fixable {
-		if f.Fix {
+		if f.Fix && f.fixSelected(issue) {
 			message = "[Fixed] " + message
 		} else {
 			message = "[Fixable] " + message
@@ -47,7 +47,7 @@ func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[
 	fmt.Fprintf(
 		f.Stdout,
 		"%s: %s (%s)\n\n",
-		colorSeverity(issue.Rule.Severity()), colorBold(message), issue.Rule.Name(),
+		f.colorSeverity(issue.Rule.Severity()), f.colorize(colorBold, message), issue.Rule.Name(),
 	)
 	fmt.Fprintf(f.Stdout, "  on %s line %d:\n", issue.Range.Filename, issue.Range.Start.Line)
 
@@ -81,7 +81,7 @@ func (f *Formatter) prettyPrintIssueWithSource(issue *tflint.Issue, sources map[
 					"%4d: %s%s%s\n",
 					lineRange.Start.Line,
 					before,
-					colorHighlight(string(highlighted)),
+					f.colorize(colorHighlight, string(highlighted)),
 					after,
 				)
 			}
@@ -136,15 +136,23 @@ func parseSources(sources map[string][]byte) map[string]*hcl.File {
 	return ret
 }
 
-func colorSeverity(severity tflint.Severity) string {
+func (f *Formatter) colorSeverity(severity tflint.Severity) string {
 	switch severity {
 	case sdk.ERROR:
-		return colorError(severity)
+		return f.colorize(colorError, severity)
 	case sdk.WARNING:
-		return colorWarning(severity)
+		return f.colorize(colorWarning, severity)
 	case sdk.NOTICE:
-		return colorNotice(severity)
+		return f.colorize(colorNotice, severity)
 	default:
 		panic("Unreachable")
 	}
 }
+
+// colorize returns the colored string unless colors are disabled in the formatter.
+func (f *Formatter) colorize(c *color.Color, a ...any) string {
+	if f.NoColor {
+		return fmt.Sprint(a...)
+	}
+	return c.Sprint(a...)
+}
@@ -16,13 +16,14 @@ func Test_prettyPrint(t *testing.T) {
 	color.NoColor = true
 
 	cases := []struct {
-		Name    string
-		Issues  tflint.Issues
-		Fix     bool
-		Error   error
-		Sources map[string][]byte
-		Stdout  string
-		Stderr  string
+		Name     string
+		Issues   tflint.Issues
+		Fix      bool
+		FixRules []string
+		Error    error
+		Sources  map[string][]byte
+		Stdout   string
+		Stderr   string
 	}{
 		{
 			Name:   "no issues",
@@ -151,6 +152,36 @@ Error: [Fixed] test (test_rule)
 
 Reference: https://github.com
 
+`,
+		},
+		{
+			Name: "fixable by unselected rule",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Fixable: true,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+			},
+			Fix:      true,
+			FixRules: []string{"other_rule"},
+			Sources: map[string][]byte{
+				"test.tf": []byte("foo = 1"),
+			},
+			Stdout: `1 issue(s) found:
+
+Error: [Fixable] test (test_rule)
+
+  on test.tf line 1:
+   1: foo = 1
+
+Reference: https://github.com
+
 `,
 		},
 		{
@@ -193,7 +224,7 @@ Reference: https://github.com
 		t.Run(tc.Name, func(t *testing.T) {
 			stdout := &bytes.Buffer{}
 			stderr := &bytes.Buffer{}
-			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Fix: tc.Fix}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Fix: tc.Fix, FixRules: tc.FixRules}
 
 			formatter.prettyPrint(tc.Issues, tc.Error, tc.Sources)
 
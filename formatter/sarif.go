@@ -1,17 +1,81 @@
 package formatter
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
 	"github.com/arsiba/tofulint/tflint"
+	"github.com/go-git/go-git/v5/utils/diff"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/owenrumney/go-sarif/sarif"
+	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
-func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
+// sarifReport is a SARIF report extended with properties that go-sarif doesn't support.
+// Results have codeFlows describing module caller chains.
+type sarifReport struct {
+	*sarif.Report
+	Runs []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	*sarif.Run
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	*sarif.Tool
+	Driver *sarifToolComponent `json:"driver"`
+}
+
+type sarifToolComponent struct {
+	*sarif.ToolComponent
+	Rules []*sarifReportingDescriptor `json:"rules,omitempty"`
+}
+
+// sarifReportingDescriptor is a rule that omits an empty shortDescription,
+// as go-sarif marshals it as null, which is invalid in the schema.
+type sarifReportingDescriptor struct {
+	*sarif.ReportingDescriptor
+	ShortDescription *sarif.MultiformatMessageString `json:"shortDescription,omitempty"`
+}
+
+type sarifResult struct {
+	*sarif.Result
+	CodeFlows    []*sarifCodeFlow    `json:"codeFlows,omitempty"`
+	Suppressions []*sarifSuppression `json:"suppressions,omitempty"`
+}
+
+// sarifSuppression is a suppression that omits empty properties,
+// as go-sarif marshals them as null, which is invalid in the schema.
+type sarifSuppression struct {
+	Kind          string          `json:"kind"`
+	Justification string          `json:"justification,omitempty"`
+	Location      *sarif.Location `json:"location,omitempty"`
+}
+
+type sarifCodeFlow struct {
+	ThreadFlows []*sarifThreadFlow `json:"threadFlows"`
+}
+
+type sarifThreadFlow struct {
+	Locations []*sarifThreadFlowLocation `json:"locations"`
+}
+
+type sarifThreadFlowLocation struct {
+	Location *sarif.Location `json:"location"`
+}
+
+// sarifFingerprintKey is the key of partialFingerprints.
+// Bump the version if the fingerprint algorithm changes.
+const sarifFingerprintKey = "tofulint/v1"
+
+func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
 	report, initErr := sarif.New(sarif.Version210)
 	if initErr != nil {
 		panic(initErr)
@@ -24,44 +88,71 @@ func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 
 	report.AddRun(run)
 
-	for _, issue := range issues {
-		rule := run.AddRule(issue.Rule.Name()).WithHelpURI(issue.Rule.Link()).WithDescription("")
-
-		var level string
-		switch issue.Rule.Severity() {
-		case sdk.ERROR:
-			level = "error"
-		case sdk.NOTICE:
-			level = "note"
-		case sdk.WARNING:
-			level = "warning"
-		default:
-			panic(fmt.Errorf("Unexpected lint type: %s", issue.Rule.Severity()))
-		}
+	codeFlows := map[*sarif.Result][]*sarifCodeFlow{}
+	suppressions := map[*sarif.Result][]*sarifSuppression{}
 
-		var location *sarif.PhysicalLocation
-		if issue.Range.Filename != "" {
-			location = sarif.NewPhysicalLocation().
-				WithArtifactLocation(sarif.NewSimpleArtifactLocation(filepath.ToSlash(issue.Range.Filename)))
-
-			if !issue.Range.Empty() {
-				location.WithRegion(
-					sarif.NewRegion().
-						WithStartLine(issue.Range.Start.Line).
-						WithStartColumn(issue.Range.Start.Column).
-						WithEndLine(issue.Range.End.Line).
-						WithEndColumn(issue.Range.End.Column),
-				)
+	for _, issue := range append(append(tflint.Issues{}, issues...), f.Suppressed...) {
+		level := toSarifLevel(issue.Rule.Severity())
+
+		// Rules are registered once, even if there are multiple issues
+		rule := run.AddRule(issue.Rule.Name())
+		if rule.DefaultConfiguration == nil {
+			// helpUri must be a valid URI, so it is omitted for rules without links
+			if link := issue.Rule.Link(); link != "" {
+				rule.WithHelpURI(link)
 			}
+			rule.DefaultConfiguration = &sarif.ReportingConfiguration{Enabled: true, Level: level}
 		}
 
 		result := run.AddResult(rule.ID).
 			WithLevel(level).
 			WithMessage(sarif.NewTextMessage(issue.Message))
 
-		if location != nil {
+		if location := toSarifPhysicalLocation(issue.Range); location != nil {
 			result.WithLocation(sarif.NewLocationWithPhysicalLocation(location))
 		}
+
+		withSource := *issue
+		if withSource.Source == nil {
+			withSource.Source = sources[issue.Range.Filename]
+		}
+		result.WithPartialFingerPrints(map[string]interface{}{sarifFingerprintKey: withSource.Fingerprint()})
+
+		// Callers are module calls from the root module to the expression where the issue was found
+		if len(issue.Callers) > 0 {
+			threadFlow := &sarifThreadFlow{Locations: []*sarifThreadFlowLocation{}}
+			for idx, caller := range issue.Callers {
+				location := toSarifPhysicalLocation(caller)
+				if location == nil {
+					continue
+				}
+				message := sarif.NewTextMessage(fmt.Sprintf("Module call chain (%d/%d)", idx+1, len(issue.Callers)))
+
+				result.WithRelatedLocation(
+					sarif.NewLocationWithPhysicalLocation(location).WithId(idx + 1).WithMessage(message),
+				)
+				threadFlow.Locations = append(threadFlow.Locations, &sarifThreadFlowLocation{
+					Location: sarif.NewLocationWithPhysicalLocation(location).WithMessage(message),
+				})
+			}
+			codeFlows[result] = []*sarifCodeFlow{{ThreadFlows: []*sarifThreadFlow{threadFlow}}}
+		}
+
+		// Issues ignored by annotations are reported as suppressed in source
+		if issue.SuppressedBy != nil {
+			suppression := &sarifSuppression{Kind: "inSource"}
+			suppression.Justification, _ = issue.SuppressedBy.Justification()
+			if location := toSarifPhysicalLocation(issue.SuppressedBy.Range()); location != nil {
+				suppression.Location = sarif.NewLocationWithPhysicalLocation(location)
+			}
+			suppressions[result] = []*sarifSuppression{suppression}
+		}
+
+		if issue.Fixable && issue.SuppressedBy == nil && f.fixSelected(issue) {
+			if fix := toSarifFix(issue, sources[issue.Range.Filename], f.Changes[issue.Range.Filename]); fix != nil {
+				result.Fixes = append(result.Fixes, fix)
+			}
+		}
 	}
 
 	errRun := sarif.NewRun("tflint-errors", "https://github.com/arsiba/tofulint")
@@ -97,8 +188,150 @@ func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 		}
 	}
 
-	stdoutErr := report.PrettyWrite(f.Stdout)
-	if stdoutErr != nil {
-		panic(stdoutErr)
+	out := &sarifReport{Report: report, Runs: make([]*sarifRun, len(report.Runs))}
+	for i, r := range report.Runs {
+		driver := &sarifToolComponent{ToolComponent: r.Tool.Driver, Rules: make([]*sarifReportingDescriptor, len(r.Tool.Driver.Rules))}
+		for j, rule := range r.Tool.Driver.Rules {
+			driver.Rules[j] = &sarifReportingDescriptor{ReportingDescriptor: rule, ShortDescription: rule.ShortDescription}
+		}
+		out.Runs[i] = &sarifRun{Run: r, Tool: &sarifTool{Tool: &r.Tool, Driver: driver}, Results: make([]*sarifResult, len(r.Results))}
+		for j, result := range r.Results {
+			out.Runs[i].Results[j] = &sarifResult{Result: result, CodeFlows: codeFlows[result], Suppressions: suppressions[result]}
+		}
 	}
+
+	marshal, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := f.Stdout.Write(marshal); err != nil {
+		panic(err)
+	}
+}
+
+func toSarifLevel(severity tflint.Severity) string {
+	switch severity {
+	case sdk.ERROR:
+		return "error"
+	case sdk.NOTICE:
+		return "note"
+	case sdk.WARNING:
+		return "warning"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
+
+func toSarifPhysicalLocation(rng hcl.Range) *sarif.PhysicalLocation {
+	if rng.Filename == "" {
+		return nil
+	}
+
+	location := sarif.NewPhysicalLocation().
+		WithArtifactLocation(sarif.NewSimpleArtifactLocation(filepath.ToSlash(rng.Filename)))
+
+	if !rng.Empty() {
+		location.WithRegion(
+			sarif.NewRegion().
+				WithStartLine(rng.Start.Line).
+				WithStartColumn(rng.Start.Column).
+				WithEndLine(rng.End.Line).
+				WithEndColumn(rng.End.Column),
+		)
+	}
+	return location
+}
+
+// toSarifFix returns a fix that describes changes made by autofixes as replacements in the original source.
+// Only replacements overlapping the issue are included. If no replacement overlaps,
+// e.g. the issue was found after the first autofix, all replacements in the file are included.
+func toSarifFix(issue *tflint.Issue, original []byte, fixed []byte) *sarif.Fix {
+	if original == nil || fixed == nil {
+		return nil
+	}
+
+	hunks := diffHunks(original, fixed)
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	overlapped := []sourceHunk{}
+	for _, hunk := range hunks {
+		if hunk.StartLine <= issue.Range.End.Line && issue.Range.Start.Line <= hunk.EndLine {
+			overlapped = append(overlapped, hunk)
+		}
+	}
+	if len(overlapped) > 0 {
+		hunks = overlapped
+	}
+
+	change := sarif.NewArtifactChange(sarif.NewSimpleArtifactLocation(filepath.ToSlash(issue.Range.Filename)))
+	for _, hunk := range hunks {
+		replacement := sarif.NewReplacement(
+			sarif.NewRegion().WithByteOffset(hunk.ByteOffset).WithByteLength(hunk.ByteLength),
+		)
+		if hunk.Inserted != "" {
+			replacement.WithInsertedContent(sarif.NewArtifactContent().WithText(hunk.Inserted))
+		}
+		change.WithReplacement(replacement)
+	}
+
+	return sarif.NewFix().
+		WithDescription(sarif.NewTextMessage(fmt.Sprintf("Autofix by %s", issue.Rule.Name()))).
+		WithArtifactChange(change)
+}
+
+// sourceHunk is a line-based change from the original source.
+// StartLine and EndLine are lines in the original source that are deleted,
+// or the line before which the text is inserted.
+type sourceHunk struct {
+	StartLine  int
+	EndLine    int
+	ByteOffset int
+	ByteLength int
+	Inserted   string
+}
+
+// diffHunks returns line-based changes to turn the original source into the fixed source.
+func diffHunks(original []byte, fixed []byte) []sourceHunk {
+	hunks := []sourceHunk{}
+
+	var current *sourceHunk
+	offset := 0
+	line := 1
+	flush := func() {
+		if current != nil {
+			hunks = append(hunks, *current)
+			current = nil
+		}
+	}
+
+	for _, d := range diff.Do(string(original), string(fixed)) {
+		if d.Text == "" {
+			continue
+		}
+
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			flush()
+			offset += len(d.Text)
+			line += strings.Count(d.Text, "\n")
+		case diffmatchpatch.DiffDelete:
+			if current == nil {
+				current = &sourceHunk{StartLine: line, EndLine: line, ByteOffset: offset}
+			}
+			current.ByteLength += len(d.Text)
+			current.EndLine = line + strings.Count(strings.TrimSuffix(d.Text, "\n"), "\n")
+			offset += len(d.Text)
+			line += strings.Count(d.Text, "\n")
+		case diffmatchpatch.DiffInsert:
+			if current == nil {
+				current = &sourceHunk{StartLine: line, EndLine: line, ByteOffset: offset}
+			}
+			current.Inserted += d.Text
+		}
+	}
+	flush()
+
+	return hunks
 }
@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"github.com/arsiba/tofulint/tflint"
 	"github.com/google/go-cmp/cmp"
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -76,8 +78,9 @@ func Test_sarifPrint(t *testing.T) {
           "rules": [
             {
               "id": "test_rule",
-              "shortDescription": {
-                "text": ""
+              "defaultConfiguration": {
+                "enabled": true,
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -105,7 +108,10 @@ func Test_sarifPrint(t *testing.T) {
                 }
               }
             }
-          ]
+          ],
+          "partialFingerprints": {
+            "tofulint/v1": "ccdfe54fcf01f77484d5c5b48633e7126939d0859c03e17ee067e685c248c565"
+          }
         }
       ]
     },
@@ -148,8 +154,9 @@ func Test_sarifPrint(t *testing.T) {
           "rules": [
             {
               "id": "test_rule",
-              "shortDescription": {
-                "text": ""
+              "defaultConfiguration": {
+                "enabled": true,
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -177,7 +184,10 @@ func Test_sarifPrint(t *testing.T) {
                 }
               }
             }
-          ]
+          ],
+          "partialFingerprints": {
+            "tofulint/v1": "ccdfe54fcf01f77484d5c5b48633e7126939d0859c03e17ee067e685c248c565"
+          }
         }
       ]
     },
@@ -220,8 +230,9 @@ func Test_sarifPrint(t *testing.T) {
           "rules": [
             {
               "id": "test_rule",
-              "shortDescription": {
-                "text": ""
+              "defaultConfiguration": {
+                "enabled": true,
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -249,7 +260,10 @@ func Test_sarifPrint(t *testing.T) {
                 }
               }
             }
-          ]
+          ],
+          "partialFingerprints": {
+            "tofulint/v1": "ccdfe54fcf01f77484d5c5b48633e7126939d0859c03e17ee067e685c248c565"
+          }
         }
       ]
     },
@@ -292,8 +306,9 @@ func Test_sarifPrint(t *testing.T) {
           "rules": [
             {
               "id": "test_rule",
-              "shortDescription": {
-                "text": ""
+              "defaultConfiguration": {
+                "enabled": true,
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -321,7 +336,10 @@ func Test_sarifPrint(t *testing.T) {
                 }
               }
             }
-          ]
+          ],
+          "partialFingerprints": {
+            "tofulint/v1": "d14ab947e9806fcd79f6b7cc8141f70c4f044c24dc1aa171dedfcb34654612e0"
+          }
         }
       ]
     },
@@ -363,8 +381,9 @@ func Test_sarifPrint(t *testing.T) {
           "rules": [
             {
               "id": "test_rule",
-              "shortDescription": {
-                "text": ""
+              "defaultConfiguration": {
+                "enabled": true,
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -386,7 +405,10 @@ func Test_sarifPrint(t *testing.T) {
                 }
               }
             }
-          ]
+          ],
+          "partialFingerprints": {
+            "tofulint/v1": "ccdfe54fcf01f77484d5c5b48633e7126939d0859c03e17ee067e685c248c565"
+          }
         }
       ]
     },
@@ -505,3 +527,336 @@ func Test_sarifPrint(t *testing.T) {
 		})
 	}
 }
+
+type testRuleWithoutLink struct {
+	testRule
+}
+
+func (r *testRuleWithoutLink) Link() string {
+	return ""
+}
+
+func Test_sarifPrint_ruleWithoutLink(t *testing.T) {
+	issues := tflint.Issues{
+		{
+			Rule:    &testRuleWithoutLink{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1},
+				End:      hcl.Pos{Line: 1, Column: 4},
+			},
+		},
+	}
+
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: "sarif"}
+	formatter.Print(issues, nil, map[string][]byte{})
+
+	var report struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []map[string]any `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := report.Runs[0].Tool.Driver.Rules
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d rules", len(rules))
+	}
+	if _, exists := rules[0]["helpUri"]; exists {
+		t.Errorf("expected no helpUri, got %#v", rules[0]["helpUri"])
+	}
+	if _, exists := rules[0]["shortDescription"]; exists {
+		t.Errorf("expected no shortDescription, got %#v", rules[0]["shortDescription"])
+	}
+	if rules[0]["id"] != "test_rule" {
+		t.Errorf("unexpected rule id: %#v", rules[0]["id"])
+	}
+}
+
+func Test_sarifPrint_callersAndFixes(t *testing.T) {
+	original := []byte(`module "child" {
+  source = "./child"
+  foo    = "bar"
+}
+
+resource "null_resource" "main" {
+  name = "old"
+}
+`)
+	fixed := []byte(`module "child" {
+  source = "./child"
+  foo    = "bar"
+}
+
+resource "null_resource" "main" {
+  name = "new"
+}
+`)
+
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "module",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 12},
+				End:      hcl.Pos{Line: 3, Column: 17},
+			},
+			Callers: []hcl.Range{
+				{
+					Filename: "main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 12},
+					End:      hcl.Pos{Line: 3, Column: 17},
+				},
+				{
+					Filename: filepath.Join("child", "main.tf"),
+					Start:    hcl.Pos{Line: 2, Column: 10},
+					End:      hcl.Pos{Line: 2, Column: 17},
+				},
+			},
+		},
+		{
+			Rule:    &testRule{},
+			Message: "fixable",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 10},
+				End:      hcl.Pos{Line: 7, Column: 15},
+			},
+			Fixable: true,
+		},
+	}
+
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{
+		Stdout:  stdout,
+		Stderr:  &bytes.Buffer{},
+		Format:  "sarif",
+		Fix:     true,
+		Changes: map[string][]byte{"main.tf": fixed},
+	}
+	formatter.Print(issues, nil, map[string][]byte{"main.tf": original})
+
+	type location struct {
+		ID               int `json:"id"`
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+		} `json:"physicalLocation"`
+	}
+	type replacement struct {
+		DeletedRegion struct {
+			ByteOffset int `json:"byteOffset"`
+			ByteLength int `json:"byteLength"`
+		} `json:"deletedRegion"`
+		InsertedContent struct {
+			Text string `json:"text"`
+		} `json:"insertedContent"`
+	}
+	var report struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+				RelatedLocations    []location        `json:"relatedLocations"`
+				CodeFlows           []struct {
+					ThreadFlows []struct {
+						Locations []struct {
+							Location location `json:"location"`
+						} `json:"locations"`
+					} `json:"threadFlows"`
+				} `json:"codeFlows"`
+				Fixes []struct {
+					ArtifactChanges []struct {
+						Replacements []replacement `json:"replacements"`
+					} `json:"artifactChanges"`
+				} `json:"fixes"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	run := report.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 {
+		t.Errorf("expected the rule to be registered once, got %d rules", len(run.Tool.Driver.Rules))
+	}
+	for _, result := range run.Results {
+		if result.PartialFingerprints["tofulint/v1"] == "" {
+			t.Errorf("expected a partial fingerprint, got %#v", result.PartialFingerprints)
+		}
+	}
+
+	related := run.Results[0].RelatedLocations
+	if len(related) != 2 || related[0].ID != 1 || related[1].PhysicalLocation.ArtifactLocation.URI != "child/main.tf" {
+		t.Errorf("unexpected related locations: %#v", related)
+	}
+	if len(run.Results[0].CodeFlows) != 1 || len(run.Results[0].CodeFlows[0].ThreadFlows[0].Locations) != 2 {
+		t.Errorf("unexpected code flows: %#v", run.Results[0].CodeFlows)
+	}
+	if len(run.Results[0].Fixes) != 0 {
+		t.Errorf("expected no fixes for the unfixable issue, got %#v", run.Results[0].Fixes)
+	}
+
+	if len(run.Results[1].Fixes) != 1 {
+		t.Fatalf("expected a fix, got %#v", run.Results[1].Fixes)
+	}
+	got := run.Results[1].Fixes[0].ArtifactChanges[0].Replacements
+	if len(got) != 1 {
+		t.Fatalf("expected a replacement, got %#v", got)
+	}
+	start := bytes.Index(original, []byte(`  name = "old"`))
+	if got[0].DeletedRegion.ByteOffset != start || got[0].DeletedRegion.ByteLength != len("  name = \"old\"\n") {
+		t.Errorf("unexpected deleted region: %#v", got[0].DeletedRegion)
+	}
+	if got[0].InsertedContent.Text != "  name = \"new\"\n" {
+		t.Errorf("unexpected inserted content: %q", got[0].InsertedContent.Text)
+	}
+}
+
+func Test_sarifPrint_suppressed(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		Format: "sarif",
+		Suppressed: tflint.Issues{
+			{
+				Rule:    &testRule{},
+				Message: "suppressed",
+				Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 4}},
+				Fixable: true,
+				SuppressedBy: &tflint.LineAnnotation{
+					Content: "test_rule",
+					Reason:  "Accepted risk",
+					Token: hclsyntax.Token{
+						Bytes: []byte("# tflint-ignore: test_rule -- Accepted risk"),
+						Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1, Byte: 0}, End: hcl.Pos{Line: 1, Column: 44, Byte: 43}},
+					},
+				},
+			},
+		},
+	}
+	formatter.Print(tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "reported",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 3, Column: 1}, End: hcl.Pos{Line: 3, Column: 4}},
+		},
+	}, nil, map[string][]byte{})
+
+	var report struct {
+		Runs []struct {
+			Results []struct {
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Suppressions []map[string]any `json:"suppressions"`
+				Fixes        []any            `json:"fixes"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	results := report.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Message.Text != "reported" || len(results[0].Suppressions) != 0 {
+		t.Errorf("expected the reported issue without suppressions, got %#v", results[0])
+	}
+
+	want := []map[string]any{
+		{
+			"kind":          "inSource",
+			"justification": "Accepted risk",
+			"location": map[string]any{
+				"physicalLocation": map[string]any{
+					"artifactLocation": map[string]any{"uri": "test.tf"},
+					"region": map[string]any{
+						"startLine":   float64(1),
+						"startColumn": float64(1),
+						"endLine":     float64(1),
+						"endColumn":   float64(44),
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, results[1].Suppressions); diff != "" {
+		t.Errorf("unexpected suppressions: %s", diff)
+	}
+	if len(results[1].Fixes) != 0 {
+		t.Errorf("expected no fixes for the suppressed issue, got %#v", results[1].Fixes)
+	}
+}
+
+func Test_diffHunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		fixed    string
+		want     []sourceHunk
+	}{
+		{
+			name:     "no changes",
+			original: "a\nb\n",
+			fixed:    "a\nb\n",
+			want:     []sourceHunk{},
+		},
+		{
+			name:     "replace",
+			original: "a\nb\nc\n",
+			fixed:    "a\nB\nc\n",
+			want:     []sourceHunk{{StartLine: 2, EndLine: 2, ByteOffset: 2, ByteLength: 2, Inserted: "B\n"}},
+		},
+		{
+			name:     "insert",
+			original: "a\nc\n",
+			fixed:    "a\nb\nc\n",
+			want:     []sourceHunk{{StartLine: 2, EndLine: 2, ByteOffset: 2, ByteLength: 0, Inserted: "b\n"}},
+		},
+		{
+			name:     "delete multiple lines",
+			original: "a\nb\nc\nd\n",
+			fixed:    "a\nd\n",
+			want:     []sourceHunk{{StartLine: 2, EndLine: 3, ByteOffset: 2, ByteLength: 4}},
+		},
+		{
+			name:     "multiple hunks",
+			original: "a\nb\nc\n",
+			fixed:    "A\nb\nC\n",
+			want: []sourceHunk{
+				{StartLine: 1, EndLine: 1, ByteOffset: 0, ByteLength: 2, Inserted: "A\n"},
+				{StartLine: 3, EndLine: 3, ByteOffset: 4, ByteLength: 2, Inserted: "C\n"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := diffHunks([]byte(test.original), []byte(test.fixed))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package gitdiff compares the working tree of a git repository with a base revision.
+// It reads the repository with a pure-Go implementation, so the git command is not required.
+package gitdiff
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// LineRange is a range of lines in a file. Both Start and End are inclusive.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Overlaps returns true if the passed range shares at least one line.
+func (r LineRange) Overlaps(other LineRange) bool {
+	return r.Start <= other.End && other.Start <= r.End
+}
+
+// Repository is a git repository to compare the working tree with the base revision.
+// Results are cached per file, and it is safe to use from multiple goroutines.
+type Repository struct {
+	root string
+	base *object.Tree
+
+	mu    sync.Mutex
+	lines map[string][]LineRange
+}
+
+// Open opens the git repository that contains the given directory,
+// and resolves the revision (e.g. "origin/main", "HEAD~1") as a base.
+func Open(dir string, revision string) (*Repository, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git worktree: %w", err)
+	}
+	root, err := filepath.Abs(worktree.Filesystem.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf(`failed to resolve revision "%s": %w`, revision, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to find commit "%s": %w`, revision, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf(`failed to read tree of "%s": %w`, revision, err)
+	}
+
+	return &Repository{
+		root:  root,
+		base:  tree,
+		lines: map[string][]LineRange{},
+	}, nil
+}
+
+// ChangedLines returns the line ranges that were added or modified in the working tree
+// compared to the base revision. The line numbers refer to the file in the working tree.
+// If the file does not exist in the base revision, all lines are considered changed.
+// Files outside of the repository are not tracked, so they have no changed lines.
+func (r *Repository) ChangedLines(path string) ([]LineRange, error) {
+	rel, inside, err := r.relPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if !inside {
+		return []LineRange{}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lines, exists := r.lines[rel]; exists {
+		return lines, nil
+	}
+
+	current, err := os.ReadFile(filepath.Join(r.root, filepath.FromSlash(rel)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.lines[rel] = []LineRange{}
+			return r.lines[rel], nil
+		}
+		return nil, err
+	}
+	base, err := r.baseContent(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lines[rel] = changedLines(base, string(current))
+	return r.lines[rel], nil
+}
+
+// DirChanged returns true if any file in the directory which has one of the
+// given extensions was added, modified, or removed compared to the base revision.
+// Subdirectories are not considered. Directories outside of the repository are never changed.
+func (r *Repository) DirChanged(dir string, exts ...string) (bool, error) {
+	rel, inside, err := r.relPath(dir)
+	if err != nil {
+		return false, err
+	}
+	if !inside {
+		return false, nil
+	}
+
+	hasExt := func(name string) bool {
+		for _, ext := range exts {
+			if strings.HasSuffix(name, ext) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Files that exist in the working tree
+	seen := map[string]bool{}
+	entries, err := os.ReadDir(filepath.Join(r.root, filepath.FromSlash(rel)))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !hasExt(entry.Name()) {
+			continue
+		}
+		seen[entry.Name()] = true
+
+		lines, err := r.ChangedLines(filepath.Join(r.root, filepath.FromSlash(rel), entry.Name()))
+		if err != nil {
+			return false, err
+		}
+		if len(lines) > 0 {
+			return true, nil
+		}
+	}
+
+	// Files that exist only in the base revision
+	tree := r.base
+	if rel != "." {
+		tree, err = r.base.Tree(rel)
+		if err != nil {
+			if errors.Is(err, object.ErrDirectoryNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	for _, entry := range tree.Entries {
+		if !entry.Mode.IsFile() || !hasExt(entry.Name) {
+			continue
+		}
+		if !seen[entry.Name] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Repository) baseContent(rel string) (string, error) {
+	file, err := r.base.File(rel)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return file.Contents()
+}
+
+// relPath returns the slash-separated path relative to the repository root.
+// The second return value is false if the path is outside of the repository.
+func (r *Repository) relPath(path string) (string, bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false, err
+	}
+	rel, err := filepath.Rel(r.root, abs)
+	if err != nil {
+		return "", false, err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false, nil
+	}
+	return filepath.ToSlash(rel), true, nil
+}
+
+// changedLines returns the line ranges inserted into dst.
+// Lines that were only removed have no counterpart in dst and are not included.
+func changedLines(src string, dst string) []LineRange {
+	ret := []LineRange{}
+	line := 1
+
+	for _, d := range diff.Do(src, dst) {
+		if d.Text == "" {
+			continue
+		}
+		count := strings.Count(d.Text, "\n")
+		if !strings.HasSuffix(d.Text, "\n") {
+			count++
+		}
+
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			line += count
+		case diffmatchpatch.DiffInsert:
+			ret = append(ret, LineRange{Start: line, End: line + count - 1})
+			line += count
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,189 @@
+package gitdiff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newFixtureRepository creates a git repository that has committed the passed files,
+// and returns the root directory.
+func newFixtureRepository(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFiles(t, dir, files)
+	for name := range files {
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = worktree.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_ChangedLines(t *testing.T) {
+	dir := newFixtureRepository(t, map[string]string{
+		"main.tf": `resource "null_resource" "foo" {
+  triggers = {
+    foo = "bar"
+  }
+}
+`,
+		"unchanged.tf": `variable "foo" {}
+`,
+	})
+	writeFiles(t, dir, map[string]string{
+		"main.tf": `# comment
+resource "null_resource" "foo" {
+  triggers = {
+    foo = "baz"
+  }
+}
+`,
+		"new.tf": `variable "bar" {}
+variable "baz" {}
+`,
+	})
+
+	repo, err := Open(dir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []LineRange
+	}{
+		{
+			name: "modified",
+			path: "main.tf",
+			want: []LineRange{{Start: 1, End: 1}, {Start: 4, End: 4}},
+		},
+		{
+			name: "unchanged",
+			path: "unchanged.tf",
+			want: []LineRange{},
+		},
+		{
+			name: "added",
+			path: "new.tf",
+			want: []LineRange{{Start: 1, End: 2}},
+		},
+		{
+			name: "not found",
+			path: "not_found.tf",
+			want: []LineRange{},
+		},
+		{
+			name: "outside",
+			path: "../outside.tf",
+			want: []LineRange{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := repo.ChangedLines(filepath.Join(dir, test.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_DirChanged(t *testing.T) {
+	dir := newFixtureRepository(t, map[string]string{
+		"modified/main.tf":  `variable "foo" {}`,
+		"unchanged/main.tf": `variable "foo" {}`,
+		"removed/main.tf":   `variable "foo" {}`,
+		"removed/other.tf":  `variable "bar" {}`,
+		"others/main.tf":    `variable "foo" {}`,
+	})
+	writeFiles(t, dir, map[string]string{
+		"modified/main.tf":   `variable "bar" {}`,
+		"others/README.md":   `# README`,
+		"added/main.tofu":    `variable "foo" {}`,
+		"unchanged/sub/a.tf": `variable "foo" {}`,
+	})
+	if err := os.Remove(filepath.Join(dir, "removed", "other.tf")); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(filepath.Join(dir, "modified"), "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{dir: "modified", want: true},
+		{dir: "unchanged", want: false},
+		{dir: "removed", want: true},
+		{dir: "others", want: false},
+		{dir: "added", want: true},
+		{dir: "not_found", want: false},
+		{dir: "..", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dir, func(t *testing.T) {
+			got, err := repo.DirChanged(filepath.Join(dir, test.dir), ".tf", ".tofu")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_Open_invalidRevision(t *testing.T) {
+	dir := newFixtureRepository(t, map[string]string{"main.tf": ""})
+
+	_, err := Open(dir, "not_found")
+	if err == nil {
+		t.Fatal("expected an error, but got nil")
+	}
+}
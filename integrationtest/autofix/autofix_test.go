@@ -35,6 +35,11 @@ func TestIntegration(t *testing.T) {
 			Command: "./tflint --format json --fix",
 			Dir:     "simple",
 		},
+		{
+			Name:    "dry run",
+			Command: "./tflint --format json --fix --dry-run",
+			Dir:     "dry_run",
+		},
 		{
 			Name:    "multiple fix in a file",
 			Command: "./tflint --format json --fix",
@@ -0,0 +1 @@
+// autofixed
@@ -173,7 +173,7 @@ func TestIntegration(t *testing.T) {
 			command: "./tflint --format awesome",
 			dir:     "no_issues",
 			status:  cmd.ExitCodeError,
-			stderr:  "Invalid value `awesome' for option",
+			stderr:  "awesome is invalid format",
 		},
 		{
 			name:    "invalid rule name",
@@ -182,6 +182,27 @@ func TestIntegration(t *testing.T) {
 			status:  cmd.ExitCodeError,
 			stderr:  "Rule not found: nosuchrule",
 		},
+		{
+			name:    "validate config",
+			command: "./tflint --validate-config",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeOK,
+			stdout:  "The config is valid",
+		},
+		{
+			name:    "validate config with misspelled rule name",
+			command: "./tflint --validate-config --enable-rule aws_instance_example_typ",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  `No enabled plugins provide the rule "aws_instance_example_typ". Did you mean "aws_instance_example_type"?`,
+		},
+		{
+			name:    "validate config with non-default format",
+			command: "./tflint --validate-config --format json",
+			dir:     "no_issues",
+			status:  cmd.ExitCodeError,
+			stderr:  "--validate-config supports only the default format",
+		},
 		{
 			name:    "issues found",
 			command: "./tflint",
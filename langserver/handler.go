@@ -196,7 +196,7 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 	}
 	variables = append(variables, cliVars)
 
-	runner, err := tflint.NewRunner(h.rootDir, h.config, annotations, configs, variables...)
+	runner, err := tflint.NewRunner(loader.ContextMeta(), h.config, annotations, configs, variables...)
 	if err != nil {
 		return ret, fmt.Errorf("Failed to initialize a runner: %w", err)
 	}
@@ -205,6 +205,9 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 		return ret, fmt.Errorf("Failed to prepare rule checking: %w", err)
 	}
 	runners = append(runners, runner)
+	for _, runner := range runners {
+		runner.EmitVariableValidationIssues()
+	}
 
 	config := h.config.ToPluginConfig()
 	for name, ruleset := range h.plugin.RuleSets {
@@ -0,0 +1,68 @@
+package opentofu
+
+import (
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Check represents a "check" block in a module or file.
+// Scoped data sources in check blocks are not represented.
+type Check struct {
+	Name    string
+	Asserts []*CheckRule
+
+	DeclRange hcl.Range
+}
+
+// CheckRule represents an "assert" block in a check block,
+// or a "validation" block in a variable block.
+type CheckRule struct {
+	Condition    hcl.Expression
+	ErrorMessage hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+func decodeCheckBlock(block *hclext.Block) *Check {
+	c := &Check{
+		Name:      block.Labels[0],
+		Asserts:   []*CheckRule{},
+		DeclRange: block.DefRange,
+	}
+
+	for _, inner := range block.Body.Blocks {
+		if inner.Type != "assert" {
+			continue
+		}
+		rule := &CheckRule{
+			DeclRange: inner.DefRange,
+		}
+		if attr, exists := inner.Body.Attributes["condition"]; exists {
+			rule.Condition = attr.Expr
+		}
+		if attr, exists := inner.Body.Attributes["error_message"]; exists {
+			rule.ErrorMessage = attr.Expr
+		}
+		c.Asserts = append(c.Asserts, rule)
+	}
+
+	return c
+}
+
+var checkBlockSchema = &hclext.BodySchema{
+	Blocks: []hclext.BlockSchema{
+		{
+			Type: "assert",
+			Body: &hclext.BodySchema{
+				Attributes: []hclext.AttributeSchema{
+					{
+						Name: "condition",
+					},
+					{
+						Name: "error_message",
+					},
+				},
+			},
+		},
+	},
+}
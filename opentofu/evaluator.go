@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/agext/levenshtein"
 	"github.com/arsiba/tofulint-plugin-sdk/hclext"
@@ -20,6 +22,11 @@ import (
 type ContextMeta struct {
 	Env                string
 	OriginalWorkingDir string
+
+	// WorkingDir is the directory where the configuration is loaded from.
+	// Relative paths passed to functions like file() are resolved against it.
+	// If empty, the current directory is used.
+	WorkingDir string
 }
 
 type CallStack struct {
@@ -80,7 +87,17 @@ type Evaluator struct {
 	Config         *Config
 	VariableValues map[string]map[string]cty.Value
 	CallStack      *CallStack
-	localCache     map[string]cty.Value
+
+	// The evaluator of the root module is shared by goroutines inspecting module calls,
+	// so the caches are guarded by the mutex.
+	cacheMu       sync.Mutex
+	localCache    map[string]cty.Value
+	resourceCache map[string]cty.Value
+	outputCache   map[string]cty.Value
+
+	// Representatives expands resources whose count/for_each is unknown
+	// to a representative instance. See also lang.Scope.
+	Representatives bool
 }
 
 func (e *Evaluator) EvaluateExpr(expr hcl.Expression, wantType cty.Type) (cty.Value, hcl.Diagnostics) {
@@ -97,6 +114,55 @@ func (e *Evaluator) ExpandBlock(body hcl.Body, schema *hclext.BodySchema) (hcl.B
 	return e.scope().ExpandBlock(body, schema)
 }
 
+// WithRepresentatives returns a copy of the evaluator that expands resources
+// whose count/for_each is unknown to a representative instance.
+func (e *Evaluator) WithRepresentatives() *Evaluator {
+	if e == nil {
+		return nil
+	}
+	// The caches are not shared because they are guarded by the mutex of each evaluator
+	return &Evaluator{
+		Meta:            e.Meta,
+		ModulePath:      e.ModulePath,
+		Config:          e.Config,
+		VariableValues:  e.VariableValues,
+		CallStack:       e.CallStack,
+		Representatives: true,
+	}
+}
+
+// ResetCache discards the cached values of locals, resources and module outputs.
+// It should be called when the module is rebuilt, as the cached values may be outdated.
+func (e *Evaluator) ResetCache() {
+	if e == nil {
+		return
+	}
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	e.localCache = nil
+	e.resourceCache = nil
+	e.outputCache = nil
+}
+
+func (e *Evaluator) loadCache(cache *map[string]cty.Value, key string) (cty.Value, bool) {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	val, ok := (*cache)[key]
+	return val, ok
+}
+
+func (e *Evaluator) storeCache(cache *map[string]cty.Value, key string, val cty.Value) {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	if *cache == nil {
+		*cache = make(map[string]cty.Value)
+	}
+	(*cache)[key] = val
+}
+
 type evaluationData struct {
 	Evaluator  *Evaluator
 	ModulePath addrs.ModuleInstance
@@ -107,12 +173,17 @@ func (d *evaluationData) StaticValidateReferences(ctx context.Context, refs []*a
 }
 
 func (e *Evaluator) scope() *lang.Scope {
-	return &lang.Scope{
+	scope := &lang.Scope{
 		Data: &evaluationData{
 			Evaluator:  e,
 			ModulePath: e.ModulePath,
 		},
+		Representatives: e.Representatives,
+	}
+	if e.Meta != nil {
+		scope.BaseDir = e.Meta.WorkingDir
 	}
+	return scope
 }
 
 var _ lang.Data = (*evaluationData)(nil)
@@ -166,7 +237,7 @@ func (d *evaluationData) GetInputVariable(ctx context.Context, addr addrs.InputV
 		for k := range moduleConfig.Module.Variables {
 			suggestions = append(suggestions, k)
 		}
-		suggestion := nameSuggestion(addr.Name, suggestions)
+		suggestion := NameSuggestion(addr.Name, suggestions)
 		if suggestion != "" {
 			suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
 		} else {
@@ -222,11 +293,7 @@ func (d *evaluationData) GetInputVariable(ctx context.Context, addr addrs.InputV
 func (d *evaluationData) GetLocalValue(ctx context.Context, addr addrs.LocalValue, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
-	if d.Evaluator.localCache == nil {
-		d.Evaluator.localCache = make(map[string]cty.Value)
-	}
-
-	if val, ok := d.Evaluator.localCache[addr.Name]; ok {
+	if val, ok := d.Evaluator.loadCache(&d.Evaluator.localCache, addr.Name); ok {
 		return val, diags
 	}
 
@@ -241,7 +308,7 @@ func (d *evaluationData) GetLocalValue(ctx context.Context, addr addrs.LocalValu
 		for k := range moduleConfig.Module.Locals {
 			suggestions = append(suggestions, k)
 		}
-		suggestion := nameSuggestion(addr.Name, suggestions)
+		suggestion := NameSuggestion(addr.Name, suggestions)
 		if suggestion != "" {
 			suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
 		}
@@ -260,11 +327,161 @@ func (d *evaluationData) GetLocalValue(ctx context.Context, addr addrs.LocalValu
 	}
 
 	val, diags := d.Evaluator.EvaluateExpr(config.Expr, cty.DynamicPseudoType)
-	d.Evaluator.localCache[addr.Name] = val
+	d.Evaluator.storeCache(&d.Evaluator.localCache, addr.Name, val)
+	d.Evaluator.CallStack.Pop()
+	return val, diags
+}
+
+func (d *evaluationData) GetResourceAttr(ctx context.Context, addr addrs.Resource, name string, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	key := fmt.Sprintf("%s.%s", addr, name)
+	if val, ok := d.Evaluator.loadCache(&d.Evaluator.resourceCache, key); ok {
+		return val, diags
+	}
+
+	moduleConfig := d.Evaluator.Config.DescendentForInstance(d.ModulePath)
+	if moduleConfig == nil {
+		panic(fmt.Sprintf("resource read from %s, which has no configuration", d.ModulePath))
+	}
+
+	// Attributes are unknown unless they are set in the configuration. Resources with count/for_each
+	// are also unknown because references to them must be resolved for each instance.
+	config := moduleConfig.Module.ResourceByAddr(addr)
+	if config == nil || config.Count != nil || config.ForEach != nil || slices.Contains(resourceMetaArgs, name) {
+		return cty.DynamicVal, diags
+	}
+	attr, diags := config.Attribute(name)
+	if diags.HasErrors() || attr == nil {
+		return cty.DynamicVal, diags
+	}
+
+	if diags := d.Evaluator.CallStack.Push(addrs.Reference{Subject: addr, SourceRange: rng}); diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	val, diags := d.Evaluator.EvaluateExpr(attr.Expr, cty.DynamicPseudoType)
+	d.Evaluator.CallStack.Pop()
+	// If the attribute is null, the provider may set a computed value
+	if !diags.HasErrors() && val.IsNull() {
+		val = cty.UnknownVal(val.Type()).WithMarks(val.Marks())
+	}
+	d.Evaluator.storeCache(&d.Evaluator.resourceCache, key, val)
+	return val, diags
+}
+
+func (d *evaluationData) GetModuleOutput(ctx context.Context, addr addrs.ModuleCallInstanceOutput, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	if val, ok := d.Evaluator.loadCache(&d.Evaluator.outputCache, addr.String()); ok {
+		return val, diags
+	}
+
+	moduleConfig := d.Evaluator.Config.DescendentForInstance(d.ModulePath)
+	if moduleConfig == nil {
+		panic(fmt.Sprintf("module output read from %s, which has no configuration", d.ModulePath))
+	}
+
+	// Outputs are unknown if the child module is not loaded (e.g. --call-module-type=none),
+	// or if it is called with count/for_each.
+	callName := addr.Call.Call.Name
+	call := moduleConfig.Module.ModuleCalls[callName]
+	childConfig := moduleConfig.Children[callName]
+	if call == nil || childConfig == nil || call.Count != nil || call.ForEach != nil || addr.Call.Key != addrs.NoKey {
+		return cty.DynamicVal, diags
+	}
+
+	config := childConfig.Module.Outputs[addr.Name]
+	if config == nil {
+		suggestions := make([]string, 0, len(childConfig.Module.Outputs))
+		for k := range childConfig.Module.Outputs {
+			suggestions = append(suggestions, k)
+		}
+		suggestion := NameSuggestion(addr.Name, suggestions)
+		if suggestion != "" {
+			suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  `Unsupported attribute`,
+			Detail:   fmt.Sprintf(`This object does not have an attribute named %q.%s`, addr.Name, suggestion),
+			Subject:  rng.Ptr(),
+		})
+		return cty.DynamicVal, diags
+	}
+	if config.Expr == nil {
+		return cty.DynamicVal, diags
+	}
+
+	if diags := d.Evaluator.CallStack.Push(addrs.Reference{Subject: addr, SourceRange: rng}); diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+	inputs, diags := d.moduleCallInputs(callName, childConfig)
 	d.Evaluator.CallStack.Pop()
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	variableValues, diags := VariableValues(childConfig, inputs)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+	// The output is evaluated in the child module context, the same as the runner for the child module.
+	// Since names are resolved per module, the child module has its own call stack.
+	child := &Evaluator{
+		Meta:           d.Evaluator.Meta,
+		ModulePath:     childConfig.Path.UnkeyedInstanceShim(),
+		Config:         d.Evaluator.Config,
+		VariableValues: variableValues,
+		CallStack:      NewCallStack(),
+	}
+	val, diags := child.EvaluateExpr(config.Expr, cty.DynamicPseudoType)
+	if config.Sensitive {
+		val = val.Mark(marks.Sensitive)
+	}
+
+	d.Evaluator.storeCache(&d.Evaluator.outputCache, addr.String(), val)
 	return val, diags
 }
 
+// moduleCallInputs evaluates the arguments of the given module call as input values
+// for the variables declared in the child module.
+func (d *evaluationData) moduleCallInputs(name string, childConfig *Config) (InputValues, hcl.Diagnostics) {
+	moduleConfig := d.Evaluator.Config.DescendentForInstance(d.ModulePath)
+
+	schema := &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body:       &hclext.BodySchema{},
+			},
+		},
+	}
+	for _, v := range childConfig.Module.Variables {
+		schema.Blocks[0].Body.Attributes = append(schema.Blocks[0].Body.Attributes, hclext.AttributeSchema{Name: v.Name})
+	}
+
+	content, diags := moduleConfig.Module.PartialContent(schema, nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	inputs := InputValues{}
+	for _, block := range content.Blocks {
+		if block.Labels[0] != name {
+			continue
+		}
+		for varName, attr := range block.Body.Attributes {
+			val, valDiags := d.Evaluator.EvaluateExpr(attr.Expr, cty.DynamicPseudoType)
+			diags = diags.Extend(valDiags)
+			inputs[varName] = &InputValue{Value: val}
+		}
+	}
+	return inputs, diags
+}
+
 func (d *evaluationData) GetPathAttr(ctx context.Context, addr addrs.PathAttr, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 	var wd string
@@ -310,7 +527,7 @@ func (d *evaluationData) GetPathAttr(ctx context.Context, addr addrs.PathAttr, r
 		return cty.StringVal(filepath.ToSlash(d.Evaluator.Config.Module.SourceDir)), diags
 
 	default:
-		suggestion := nameSuggestion(addr.Name, []string{"cwd", "module", "root"})
+		suggestion := NameSuggestion(addr.Name, []string{"cwd", "module", "root"})
 		if suggestion != "" {
 			suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
 		}
@@ -353,11 +570,16 @@ func (d *evaluationData) GetTerraformAttr(ctx context.Context, addr addrs.Terraf
 	}
 }
 
-func nameSuggestion(given string, suggestions []string) string {
-	for _, suggestion := range suggestions {
-		if levenshtein.Distance(given, suggestion, nil) < 3 {
-			return suggestion
+// NameSuggestion returns the most similar name to the given one within a Levenshtein distance of 3.
+// It returns an empty string if there are no similar names.
+func NameSuggestion(given string, suggestions []string) string {
+	suggestion := ""
+	minDistance := 3
+	for _, s := range suggestions {
+		if distance := levenshtein.Distance(given, s, nil); distance < minDistance {
+			suggestion = s
+			minDistance = distance
 		}
 	}
-	return ""
+	return suggestion
 }
@@ -45,6 +45,7 @@ func TestEvaluateExpr(t *testing.T) {
 	tests := []struct {
 		name     string
 		config   string
+		override string
 		inputs   []InputValues
 		context  *ContextMeta
 		expr     hcl.Expression
@@ -767,6 +768,131 @@ locals {
 			want:     `cty.UnknownVal(cty.String)`,
 			errCheck: neverHappend,
 		},
+		{
+			name: "resource attribute",
+			config: `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}`,
+			expr:     expr(`aws_vpc.main.cidr_block`),
+			ty:       cty.String,
+			want:     `cty.StringVal("10.0.0.0/16")`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "resource attribute using variables and locals",
+			config: `
+variable "prefix" {
+  default = "10.0"
+}
+locals {
+  cidr_block = "${var.prefix}.0.0/16"
+}
+resource "aws_vpc" "main" {
+  cidr_block = local.cidr_block
+}
+resource "aws_subnet" "main" {
+  cidr_block = cidrsubnet(aws_vpc.main.cidr_block, 8, 1)
+}`,
+			expr:     expr(`aws_subnet.main.cidr_block`),
+			ty:       cty.String,
+			want:     `cty.StringVal("10.0.1.0/24")`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "computed resource attribute",
+			config: `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}`,
+			expr:     expr(`aws_vpc.main.id`),
+			ty:       cty.String,
+			want:     `cty.UnknownVal(cty.String)`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "null resource attribute",
+			config: `
+resource "aws_vpc" "main" {
+  cidr_block = null
+}`,
+			expr:     expr(`aws_vpc.main.cidr_block`),
+			ty:       cty.String,
+			want:     `cty.UnknownVal(cty.String)`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "resource attribute in override file",
+			config: `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}`,
+			override: `
+resource "aws_vpc" "main" {
+  cidr_block = "172.16.0.0/16"
+}`,
+			expr:     expr(`aws_vpc.main.cidr_block`),
+			ty:       cty.String,
+			want:     `cty.StringVal("172.16.0.0/16")`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "resource attribute with count",
+			config: `
+resource "aws_vpc" "main" {
+  count      = 1
+  cidr_block = "10.0.0.0/16"
+}`,
+			expr:     expr(`aws_vpc.main[0].cidr_block`),
+			ty:       cty.String,
+			want:     `cty.UnknownVal(cty.String)`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "entire resource",
+			config: `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}`,
+			expr:     expr(`aws_vpc.main`),
+			ty:       cty.DynamicPseudoType,
+			want:     `cty.DynamicVal`,
+			errCheck: neverHappend,
+		},
+		{
+			name:     "undeclared resource",
+			expr:     expr(`aws_vpc.main.cidr_block`),
+			ty:       cty.String,
+			want:     `cty.UnknownVal(cty.String)`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "data source attribute",
+			config: `
+data "aws_ami" "main" {
+  owners = ["amazon"]
+}`,
+			expr:     expr(`data.aws_ami.main.owners[0]`),
+			ty:       cty.String,
+			want:     `cty.StringVal("amazon")`,
+			errCheck: neverHappend,
+		},
+		{
+			name: "circular resource references",
+			config: `
+resource "aws_vpc" "foo" {
+  cidr_block = aws_vpc.bar.cidr_block
+}
+resource "aws_vpc" "bar" {
+  cidr_block = aws_vpc.foo.cidr_block
+}`,
+			expr: expr(`aws_vpc.foo.cidr_block`),
+			ty:   cty.String,
+			want: `cty.UnknownVal(cty.String)`,
+			errCheck: func(diags hcl.Diagnostics) bool {
+				return diags.Error() != `main.tf:6,16-27: circular reference found; aws_vpc.foo -> aws_vpc.bar -> aws_vpc.foo`
+			},
+		},
 		{
 			name:     "bound expr",
 			expr:     hclext.BindValue(cty.StringVal("foo"), expr(`each.value`)),
@@ -801,6 +927,11 @@ variable "foo" {
 			if err := fs.WriteFile("main.tf", []byte(test.config), os.ModePerm); err != nil {
 				t.Fatal(err)
 			}
+			if test.override != "" {
+				if err := fs.WriteFile("main_override.tf", []byte(test.override), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
 
 			parser := NewParser(fs)
 			mod, diags := parser.LoadConfigDir(".", ".")
@@ -2162,3 +2293,379 @@ resource "aws_instance" "main" {
 		})
 	}
 }
+
+func TestExpandBlock_representatives(t *testing.T) {
+	schema := &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body:       &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "key"}, {Name: "value"}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config string
+		want   *hclext.BodyContent
+	}{
+		{
+			name: "count is unknown",
+			config: `
+variable "count" {}
+
+resource "aws_instance" "main" {
+  count = var.count
+  value = count.index
+}`,
+			want: &hclext.BodyContent{
+				Attributes: hclext.Attributes{},
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "main"},
+						Body:   &hclext.BodyContent{Attributes: hclext.Attributes{"value": {Name: "value", Expr: hcl.StaticExpr(cty.UnknownVal(cty.Number), hcl.Range{})}}, Blocks: hclext.Blocks{}},
+					},
+				},
+			},
+		},
+		{
+			name: "count is known",
+			config: `
+resource "aws_instance" "main" {
+  count = 2
+  value = count.index
+}`,
+			want: &hclext.BodyContent{
+				Attributes: hclext.Attributes{},
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "main"},
+						Body:   &hclext.BodyContent{Attributes: hclext.Attributes{"value": {Name: "value", Expr: hcl.StaticExpr(cty.NumberIntVal(0), hcl.Range{})}}, Blocks: hclext.Blocks{}},
+					},
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "main"},
+						Body:   &hclext.BodyContent{Attributes: hclext.Attributes{"value": {Name: "value", Expr: hcl.StaticExpr(cty.NumberIntVal(1), hcl.Range{})}}, Blocks: hclext.Blocks{}},
+					},
+				},
+			},
+		},
+		{
+			name: "for_each is unknown map",
+			config: `
+variable "for_each" {
+  type = map(number)
+}
+
+resource "aws_instance" "main" {
+  for_each = var.for_each
+  key      = each.key
+  value    = each.value
+}`,
+			want: &hclext.BodyContent{
+				Attributes: hclext.Attributes{},
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "main"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{
+								"key":   {Name: "key", Expr: hcl.StaticExpr(cty.UnknownVal(cty.String), hcl.Range{})},
+								"value": {Name: "value", Expr: hcl.StaticExpr(cty.UnknownVal(cty.Number), hcl.Range{})},
+							},
+							Blocks: hclext.Blocks{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "for_each is unknown set",
+			config: `
+variable "for_each" {
+  type = set(string)
+}
+
+resource "aws_instance" "main" {
+  for_each = var.for_each
+  key      = each.key
+  value    = each.value
+}`,
+			want: &hclext.BodyContent{
+				Attributes: hclext.Attributes{},
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "main"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{
+								"key":   {Name: "key", Expr: hcl.StaticExpr(cty.UnknownVal(cty.String), hcl.Range{})},
+								"value": {Name: "value", Expr: hcl.StaticExpr(cty.UnknownVal(cty.String), hcl.Range{})},
+							},
+							Blocks: hclext.Blocks{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "for_each is unevaluable",
+			config: `
+resource "aws_instance" "main" {
+  for_each = module.meta.for_each
+  key      = each.key
+  value    = each.value
+}`,
+			want: &hclext.BodyContent{
+				Attributes: hclext.Attributes{},
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "main"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{
+								"key":   {Name: "key", Expr: hcl.StaticExpr(cty.UnknownVal(cty.String), hcl.Range{})},
+								"value": {Name: "value", Expr: hcl.StaticExpr(cty.DynamicVal, hcl.Range{})},
+							},
+							Blocks: hclext.Blocks{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			if err := fs.WriteFile("main.tf", []byte(test.config), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			file, diags := hclsyntax.ParseConfig([]byte(test.config), "main.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			parser := NewParser(fs)
+			mod, diags := parser.LoadConfigDir(".", ".")
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			config, diags := BuildConfig(context.Background(), mod, ModuleWalkerFunc(func(ctx context.Context, req *ModuleRequest) (*Module, *version.Version, hcl.Diagnostics) {
+				return nil, nil, nil
+			}))
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			variableValues, diags := VariableValues(config)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			evaluator := (&Evaluator{
+				Meta:           &ContextMeta{Env: Workspace()},
+				ModulePath:     config.Path.UnkeyedInstanceShim(),
+				Config:         config,
+				VariableValues: variableValues,
+				CallStack:      NewCallStack(),
+			}).WithRepresentatives()
+
+			expanded, diags := evaluator.ExpandBlock(file.Body, schema)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			got, diags := hclext.PartialContent(expanded, schema)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(hclext.Block{}, "TypeRange", "LabelRanges"),
+				cmpopts.IgnoreFields(hclext.Attribute{}, "NameRange"),
+				cmpopts.IgnoreFields(hcl.Range{}, "Start", "End", "Filename"),
+				cmp.Comparer(func(x, y hcl.Expression) bool {
+					xv, diags := evaluator.EvaluateExpr(x, cty.DynamicPseudoType)
+					if diags.HasErrors() {
+						t.Fatal(diags)
+					}
+					yv, diags := evaluator.EvaluateExpr(y, cty.DynamicPseudoType)
+					if diags.HasErrors() {
+						t.Fatal(diags)
+					}
+					return xv.RawEquals(yv)
+				}),
+			}
+			if diff := cmp.Diff(got, test.want, opts); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEvaluateExpr_moduleOutputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expr     string
+		want     string
+		errCheck func(hcl.Diagnostics) bool
+	}{
+		{
+			name: "output using input variables",
+			files: map[string]string{
+				"main.tf": `
+module "network" {
+  source = "./modules/network"
+  cidr   = "10.0.0.0/16"
+}`,
+				"modules/network/main.tf": `
+variable "cidr" {}
+variable "suffix" {
+  default = "main"
+}
+resource "aws_vpc" "main" {
+  cidr_block = var.cidr
+}
+output "cidr" {
+  value = aws_vpc.main.cidr_block
+}
+output "name" {
+  value = "vpc-${var.suffix}"
+}
+output "vpc_id" {
+  value = aws_vpc.main.id
+}`,
+			},
+			expr: `[module.network.cidr, module.network.name, module.network.vpc_id]`,
+			want: `cty.TupleVal([]cty.Value{cty.StringVal("10.0.0.0/16"), cty.StringVal("vpc-main"), cty.DynamicVal})`,
+		},
+		{
+			name: "sensitive output",
+			files: map[string]string{
+				"main.tf": `
+module "network" {
+  source = "./modules/network"
+}`,
+				"modules/network/main.tf": `
+output "secret" {
+  value     = "foo"
+  sensitive = true
+}`,
+			},
+			expr: `module.network.secret`,
+			want: `cty.StringVal("foo").Mark(marks.Sensitive)`,
+		},
+		{
+			name: "module with count",
+			files: map[string]string{
+				"main.tf": `
+module "network" {
+  source = "./modules/network"
+  count  = 1
+}`,
+				"modules/network/main.tf": `
+output "name" {
+  value = "foo"
+}`,
+			},
+			expr: `module.network[0].name`,
+			want: `cty.DynamicVal`,
+		},
+		{
+			name: "undeclared output",
+			files: map[string]string{
+				"main.tf": `
+module "network" {
+  source = "./modules/network"
+}`,
+				"modules/network/main.tf": `
+output "name" {
+  value = "foo"
+}`,
+			},
+			expr: `module.network.nam`,
+			want: `cty.DynamicVal`,
+			errCheck: func(diags hcl.Diagnostics) bool {
+				return diags.Error() != `:1,1-19: Unsupported attribute; This object does not have an attribute named "nam". Did you mean "name"?`
+			},
+		},
+		{
+			name: "circular references through module inputs",
+			files: map[string]string{
+				"main.tf": `
+locals {
+  name = module.network.name
+}
+module "network" {
+  source = "./modules/network"
+  name   = local.name
+}`,
+				"modules/network/main.tf": `
+variable "name" {}
+output "name" {
+  value = var.name
+}`,
+			},
+			expr: `local.name`,
+			want: `cty.DynamicVal`,
+			errCheck: func(diags hcl.Diagnostics) bool {
+				return diags.Error() != `main.tf:7,12-22: circular reference found; local.name -> module.network.name -> local.name`
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			for name, src := range test.files {
+				if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			parser := NewParser(fs)
+			mod, diags := parser.LoadConfigDir(".", ".")
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			config, diags := BuildConfig(context.Background(), mod, ModuleWalkerFunc(func(ctx context.Context, req *ModuleRequest) (*Module, *version.Version, hcl.Diagnostics) {
+				mod, diags := parser.LoadConfigDir(".", filepath.Join("modules", req.Name))
+				return mod, nil, diags
+			}))
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			variableValues, diags := VariableValues(config)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			evaluator := &Evaluator{
+				Meta:           &ContextMeta{Env: Workspace()},
+				ModulePath:     config.Path.UnkeyedInstanceShim(),
+				Config:         config,
+				VariableValues: variableValues,
+				CallStack:      NewCallStack(),
+			}
+
+			expr, diags := hclsyntax.ParseExpression([]byte(test.expr), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			got, diags := evaluator.EvaluateExpr(expr, cty.DynamicPseudoType)
+			if test.errCheck == nil {
+				test.errCheck = func(diags hcl.Diagnostics) bool { return diags.HasErrors() }
+			}
+			if test.errCheck(diags) {
+				t.Fatal(diags)
+			}
+
+			if test.want != got.GoString() {
+				t.Errorf("want: %s, got: %s", test.want, got.GoString())
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package opentofu
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// NewWorkingDirFs returns a filesystem that resolves relative paths against the given
+// directory instead of the current directory. Absolute paths are passed through as is.
+//
+// Unlike afero.BasePathFs, paths are not restricted to the directory, so references
+// to the parent directory (e.g. local modules like "../modules/foo") work as usual.
+// This allows loading configurations in a different directory without os.Chdir,
+// which affects the whole process.
+func NewWorkingDirFs(fs afero.Fs, dir string) afero.Fs {
+	if dir == "" || dir == "." {
+		return fs
+	}
+	return &workingDirFs{fs: fs, dir: dir}
+}
+
+// WorkingDir returns the directory where relative paths are resolved in the filesystem.
+// It returns "." if the filesystem is not created by NewWorkingDirFs.
+func WorkingDir(fs afero.Fs) string {
+	if w, ok := fs.(*workingDirFs); ok {
+		return w.dir
+	}
+	return "."
+}
+
+type workingDirFs struct {
+	fs  afero.Fs
+	dir string
+}
+
+var _ afero.Fs = (*workingDirFs)(nil)
+
+func (w *workingDirFs) resolve(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(w.dir, name)
+}
+
+func (w *workingDirFs) Create(name string) (afero.File, error) {
+	f, err := w.fs.Create(w.resolve(name))
+	if err != nil {
+		return nil, err
+	}
+	return &workingDirFile{File: f, name: name}, nil
+}
+
+func (w *workingDirFs) Mkdir(name string, perm os.FileMode) error {
+	return w.fs.Mkdir(w.resolve(name), perm)
+}
+
+func (w *workingDirFs) MkdirAll(path string, perm os.FileMode) error {
+	return w.fs.MkdirAll(w.resolve(path), perm)
+}
+
+func (w *workingDirFs) Open(name string) (afero.File, error) {
+	f, err := w.fs.Open(w.resolve(name))
+	if err != nil {
+		return nil, err
+	}
+	return &workingDirFile{File: f, name: name}, nil
+}
+
+func (w *workingDirFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	f, err := w.fs.OpenFile(w.resolve(name), flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return &workingDirFile{File: f, name: name}, nil
+}
+
+func (w *workingDirFs) Remove(name string) error {
+	return w.fs.Remove(w.resolve(name))
+}
+
+func (w *workingDirFs) RemoveAll(path string) error {
+	return w.fs.RemoveAll(w.resolve(path))
+}
+
+func (w *workingDirFs) Rename(oldname, newname string) error {
+	return w.fs.Rename(w.resolve(oldname), w.resolve(newname))
+}
+
+func (w *workingDirFs) Stat(name string) (os.FileInfo, error) {
+	return w.fs.Stat(w.resolve(name))
+}
+
+func (w *workingDirFs) Name() string {
+	return "WorkingDirFs"
+}
+
+func (w *workingDirFs) Chmod(name string, mode os.FileMode) error {
+	return w.fs.Chmod(w.resolve(name), mode)
+}
+
+func (w *workingDirFs) Chown(name string, uid, gid int) error {
+	return w.fs.Chown(w.resolve(name), uid, gid)
+}
+
+func (w *workingDirFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return w.fs.Chtimes(w.resolve(name), atime, mtime)
+}
+
+// workingDirFile keeps the requested name, so that the file names
+// in diagnostics are the same as when the current directory is changed.
+type workingDirFile struct {
+	afero.File
+	name string
+}
+
+func (f *workingDirFile) Name() string {
+	return f.name
+}
@@ -0,0 +1,90 @@
+package opentofu
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestNewWorkingDirFs(t *testing.T) {
+	base := afero.NewMemMapFs()
+	files := map[string]string{
+		filepath.Join("/", "work", "main.tf"):     "main",
+		filepath.Join("/", "shared", "module.tf"): "module",
+	}
+	for name, src := range files {
+		if err := afero.WriteFile(base, name, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fs := afero.Afero{Fs: NewWorkingDirFs(base, filepath.Join("/", "work"))}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "relative path",
+			path: "main.tf",
+			want: "main",
+		},
+		{
+			name: "parent directory",
+			path: filepath.Join("..", "shared", "module.tf"),
+			want: "module",
+		},
+		{
+			name: "absolute path",
+			path: filepath.Join("/", "shared", "module.tf"),
+			want: "module",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := fs.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			if f.Name() != test.path {
+				t.Errorf("file name: got %s, want %s", f.Name(), test.path)
+			}
+
+			got, err := fs.ReadFile(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	if exists, _ := fs.Exists("module.tf"); exists {
+		t.Error("module.tf should not be found in the working directory")
+	}
+}
+
+func TestNewWorkingDirFs_currentDir(t *testing.T) {
+	base := afero.NewMemMapFs()
+
+	if got := NewWorkingDirFs(base, "."); got != base {
+		t.Errorf("expected the passed filesystem, but got %#v", got)
+	}
+}
+
+func TestWorkingDir(t *testing.T) {
+	base := afero.NewMemMapFs()
+
+	if got := WorkingDir(NewWorkingDirFs(base, "sub/dir")); got != "sub/dir" {
+		t.Errorf("got %s, want sub/dir", got)
+	}
+	if got := WorkingDir(base); got != "." {
+		t.Errorf("got %s, want .", got)
+	}
+}
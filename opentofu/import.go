@@ -0,0 +1,54 @@
+package opentofu
+
+import (
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Import represents an "import" block in a module or file.
+type Import struct {
+	To       hcl.Expression
+	ID       hcl.Expression
+	ForEach  hcl.Expression
+	Provider hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+func decodeImportBlock(block *hclext.Block) *Import {
+	i := &Import{
+		DeclRange: block.DefRange,
+	}
+
+	if attr, exists := block.Body.Attributes["to"]; exists {
+		i.To = attr.Expr
+	}
+	if attr, exists := block.Body.Attributes["id"]; exists {
+		i.ID = attr.Expr
+	}
+	if attr, exists := block.Body.Attributes["for_each"]; exists {
+		i.ForEach = attr.Expr
+	}
+	if attr, exists := block.Body.Attributes["provider"]; exists {
+		i.Provider = attr.Expr
+	}
+
+	return i
+}
+
+var importBlockSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{
+			Name: "to",
+		},
+		{
+			Name: "id",
+		},
+		{
+			Name: "for_each",
+		},
+		{
+			Name: "provider",
+		},
+	},
+}
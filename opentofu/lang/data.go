@@ -36,4 +36,10 @@ type Data interface {
 	GetPathAttr(context.Context, addrs.PathAttr, hcl.Range) (cty.Value, hcl.Diagnostics)
 	GetTerraformAttr(context.Context, addrs.TerraformAttr, hcl.Range) (cty.Value, hcl.Diagnostics)
 	GetInputVariable(context.Context, addrs.InputVariable, hcl.Range) (cty.Value, hcl.Diagnostics)
+
+	// GetResourceAttr returns the value of the named attribute of the given resource.
+	// Unlike Terraform, TofuLint does not know the entire resource object, so only
+	// the referenced attributes are requested.
+	GetResourceAttr(context.Context, addrs.Resource, string, hcl.Range) (cty.Value, hcl.Diagnostics)
+	GetModuleOutput(context.Context, addrs.ModuleCallInstanceOutput, hcl.Range) (cty.Value, hcl.Diagnostics)
 }
@@ -21,6 +21,8 @@ type dataForTests struct {
 	PathAttrs               map[string]cty.Value
 	TerraformAttrs          map[string]cty.Value
 	InputVariables          map[string]cty.Value
+	ResourceAttrs           map[string]cty.Value
+	ModuleOutputs           map[string]cty.Value
 	StaticValidateReference map[string]cty.Value
 }
 
@@ -53,3 +55,17 @@ func (d *dataForTests) GetPathAttr(ctx context.Context, addr addrs.PathAttr, rng
 func (d *dataForTests) GetTerraformAttr(ctx context.Context, addr addrs.TerraformAttr, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
 	return d.TerraformAttrs[addr.Name], nil
 }
+
+func (d *dataForTests) GetResourceAttr(ctx context.Context, addr addrs.Resource, name string, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
+	if val, exists := d.ResourceAttrs[addr.String()+"."+name]; exists {
+		return val, nil
+	}
+	return cty.DynamicVal, nil
+}
+
+func (d *dataForTests) GetModuleOutput(ctx context.Context, addr addrs.ModuleCallInstanceOutput, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
+	if val, exists := d.ModuleOutputs[addr.String()]; exists {
+		return val, nil
+	}
+	return cty.DynamicVal, nil
+}
@@ -40,6 +40,9 @@ func (s *Scope) ExpandBlock(body hcl.Body, schema *hclext.BodySchema) (hcl.Body,
 	hclCtx, ctxDiags := s.EvalContext(refs, functionCalls)
 	diags = diags.Extend(ctxDiags)
 
+	if s.Representatives {
+		return tfhcl.ExpandWithRepresentatives(body, hclCtx), diags
+	}
 	return tfhcl.Expand(body, hclCtx), diags
 }
 
@@ -139,7 +142,13 @@ func (s *Scope) evalContext(refs []*addrs.Reference, selfAddr addrs.Referenceabl
 	// it, since that allows us to gather a full set of any errors and
 	// warnings, but once we've gathered all the data we'll then skip anything
 	// that's redundant in the process of populating our values map.
-	managedResources := map[string]cty.Value{}
+	// Resources are gathered by type and name. Each resource has the referenced attributes.
+	// If a resource is referenced without an attribute, the attributes are nil
+	// and the entire resource is unknown.
+	managedResources := map[string]map[string]map[string]cty.Value{}
+	dataResources := map[string]map[string]map[string]cty.Value{}
+	// Module outputs are gathered by module call name in the same way.
+	moduleOutputs := map[string]map[string]cty.Value{}
 	inputVariables := map[string]cty.Value{}
 	localValues := map[string]cty.Value{}
 	pathAttrs := map[string]cty.Value{}
@@ -162,12 +171,56 @@ func (s *Scope) evalContext(refs []*addrs.Reference, selfAddr addrs.Referenceabl
 
 		switch subj := rawSubj.(type) {
 		case addrs.Resource:
-			// Managed resources are not supported by TofuLint, but it does support arbitrary
-			// key names, so it gathers the referenced resource names.
-			if subj.Mode != addrs.ManagedResourceMode {
+			resources := managedResources
+			if subj.Mode == addrs.DataResourceMode {
+				resources = dataResources
+			}
+			if _, exists := resources[subj.Type]; !exists {
+				resources[subj.Type] = map[string]map[string]cty.Value{}
+			}
+			attrs, exists := resources[subj.Type][subj.Name]
+			if exists && attrs == nil {
+				// The entire resource is already unknown
+				continue
+			}
+
+			name, ok := resourceAttrName(ref)
+			if !ok {
+				resources[subj.Type][subj.Name] = nil
 				continue
 			}
-			managedResources[subj.Type] = cty.UnknownVal(cty.DynamicPseudoType)
+			if attrs == nil {
+				attrs = map[string]cty.Value{}
+				resources[subj.Type][subj.Name] = attrs
+			}
+			val, valDiags := normalizeRefValue(s.Data.GetResourceAttr(context.Background(), subj, name, rng))
+			diags = diags.Extend(valDiags)
+			attrs[name] = val
+
+		case addrs.ModuleCall:
+			moduleOutputs[subj.Name] = nil
+
+		case addrs.ModuleCallInstance:
+			moduleOutputs[subj.Call.Name] = nil
+
+		case addrs.ModuleCallInstanceOutput:
+			outputs, exists := moduleOutputs[subj.Call.Call.Name]
+			if exists && outputs == nil {
+				// The entire module is already unknown
+				continue
+			}
+			// Instances with keys are not supported, so the entire module is unknown
+			if subj.Call.Key != addrs.NoKey {
+				moduleOutputs[subj.Call.Call.Name] = nil
+				continue
+			}
+			if outputs == nil {
+				outputs = map[string]cty.Value{}
+				moduleOutputs[subj.Call.Call.Name] = outputs
+			}
+			val, valDiags := normalizeRefValue(s.Data.GetModuleOutput(context.Background(), subj, rng))
+			diags = diags.Extend(valDiags)
+			outputs[subj.Name] = val
 
 		case addrs.InputVariable:
 			val, valDiags := normalizeRefValue(s.Data.GetInputVariable(context.Background(), subj, rng))
@@ -205,7 +258,7 @@ func (s *Scope) evalContext(refs []*addrs.Reference, selfAddr addrs.Referenceabl
 	// at the top level where the resource type name is the root of the
 	// traversal.
 	for k, v := range managedResources {
-		vals[k] = v
+		vals[k] = referencedObjectsVal(v)
 	}
 
 	vals["var"] = cty.ObjectVal(inputVariables)
@@ -219,12 +272,51 @@ func (s *Scope) evalContext(refs []*addrs.Reference, selfAddr addrs.Referenceabl
 	vals["resource"] = cty.UnknownVal(cty.DynamicPseudoType)
 	vals["ephemeral"] = cty.UnknownVal(cty.DynamicPseudoType).Mark(marks.Ephemeral)
 	vals["data"] = cty.UnknownVal(cty.DynamicPseudoType)
+	if len(dataResources) > 0 {
+		dataVals := map[string]cty.Value{}
+		for k, v := range dataResources {
+			dataVals[k] = referencedObjectsVal(v)
+		}
+		vals["data"] = cty.ObjectVal(dataVals)
+	}
 	vals["module"] = cty.UnknownVal(cty.DynamicPseudoType)
+	if len(moduleOutputs) > 0 {
+		vals["module"] = referencedObjectsVal(moduleOutputs)
+	}
 	vals["self"] = cty.UnknownVal(cty.DynamicPseudoType)
 
 	return ctx, diags
 }
 
+// resourceAttrName returns the name of the attribute accessed by the given resource reference.
+// It returns false if the reference is not a single resource instance followed by an attribute
+// access, such as a reference to the entire resource or to an instance with a key.
+func resourceAttrName(ref *addrs.Reference) (string, bool) {
+	inst, ok := ref.Subject.(addrs.ResourceInstance)
+	if !ok || inst.Key != addrs.NoKey || len(ref.Remaining) == 0 {
+		return "", false
+	}
+	attr, ok := ref.Remaining[0].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
+
+// referencedObjectsVal returns an object of objects by name, such as resources and modules,
+// for the given referenced attributes. Objects without attributes are unknown.
+func referencedObjectsVal(objects map[string]map[string]cty.Value) cty.Value {
+	vals := make(map[string]cty.Value, len(objects))
+	for name, attrs := range objects {
+		if attrs == nil {
+			vals[name] = cty.DynamicVal
+			continue
+		}
+		vals[name] = cty.ObjectVal(attrs)
+	}
+	return cty.ObjectVal(vals)
+}
+
 func normalizeRefValue(val cty.Value, diags hcl.Diagnostics) (cty.Value, hcl.Diagnostics) {
 	if diags.HasErrors() {
 		// If there are errors then we will force an unknown result so that
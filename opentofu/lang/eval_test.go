@@ -34,6 +34,13 @@ func TestScopeEvalContext(t *testing.T) {
 		InputVariables: map[string]cty.Value{
 			"baz": cty.StringVal("boop"),
 		},
+		ResourceAttrs: map[string]cty.Value{
+			"null_resource.foo.attr":         cty.StringVal("bar"),
+			"data.null_data_source.foo.attr": cty.StringVal("baz"),
+		},
+		ModuleOutputs: map[string]cty.Value{
+			"module.foo.out": cty.StringVal("qux"),
+		},
 	}
 
 	tests := []struct {
@@ -99,78 +106,163 @@ func TestScopeEvalContext(t *testing.T) {
 		{
 			`null_resource.foo`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"foo": cty.DynamicVal,
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
 			},
 		},
 		{
 			`null_resource.foo.attr`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"foo": cty.ObjectVal(map[string]cty.Value{
+						"attr": cty.StringVal("bar"),
+					}),
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
 			},
 		},
 		{
 			`null_resource.multi`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"multi": cty.DynamicVal,
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
 			},
 		},
 		{
 			`null_resource.multi[1]`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"multi": cty.DynamicVal,
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
 			},
 		},
 		{
 			`null_resource.each["each1"]`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"each": cty.DynamicVal,
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
 			},
 		},
 		{
 			`null_resource.each["each1"].attr`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"each": cty.DynamicVal,
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
 			},
 		},
 		{
 			`foo(null_resource.multi, null_resource.multi[1])`,
 			map[string]cty.Value{
-				"null_resource": cty.DynamicVal,
-				"resource":      cty.DynamicVal,
-				"ephemeral":     cty.DynamicVal.Mark(marks.Ephemeral),
-				"data":          cty.DynamicVal,
-				"module":        cty.DynamicVal,
-				"self":          cty.DynamicVal,
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"multi": cty.DynamicVal,
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
+			},
+		},
+		{
+			`null_resource.foo.unknown`,
+			map[string]cty.Value{
+				"null_resource": cty.ObjectVal(map[string]cty.Value{
+					"foo": cty.ObjectVal(map[string]cty.Value{
+						"unknown": cty.DynamicVal,
+					}),
+				}),
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module":    cty.DynamicVal,
+				"self":      cty.DynamicVal,
+			},
+		},
+		{
+			`data.null_data_source.foo.attr`,
+			map[string]cty.Value{
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data": cty.ObjectVal(map[string]cty.Value{
+					"null_data_source": cty.ObjectVal(map[string]cty.Value{
+						"foo": cty.ObjectVal(map[string]cty.Value{
+							"attr": cty.StringVal("baz"),
+						}),
+					}),
+				}),
+				"module": cty.DynamicVal,
+				"self":   cty.DynamicVal,
+			},
+		},
+		{
+			`module.foo.out`,
+			map[string]cty.Value{
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module": cty.ObjectVal(map[string]cty.Value{
+					"foo": cty.ObjectVal(map[string]cty.Value{
+						"out": cty.StringVal("qux"),
+					}),
+				}),
+				"self": cty.DynamicVal,
+			},
+		},
+		{
+			`module.foo`,
+			map[string]cty.Value{
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module": cty.ObjectVal(map[string]cty.Value{
+					"foo": cty.DynamicVal,
+				}),
+				"self": cty.DynamicVal,
+			},
+		},
+		{
+			`module.foo[0].out`,
+			map[string]cty.Value{
+				"resource":  cty.DynamicVal,
+				"ephemeral": cty.DynamicVal.Mark(marks.Ephemeral),
+				"data":      cty.DynamicVal,
+				"module": cty.ObjectVal(map[string]cty.Value{
+					"foo": cty.DynamicVal,
+				}),
+				"self": cty.DynamicVal,
 			},
 		},
 		{
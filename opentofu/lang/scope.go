@@ -42,6 +42,11 @@ type Scope struct {
 	// then differ during apply.
 	PureOnly bool
 
+	// Representatives can be set to true to expand resources and modules whose
+	// count/for_each is unknown to a single representative instance in ExpandBlock.
+	// By default, no instances are expanded for them.
+	Representatives bool
+
 	funcsLock sync.Mutex
 	funcs     map[string]function.Function
 }
@@ -23,7 +23,9 @@ type Loader struct {
 	parser  *Parser
 	modules moduleMgr
 
-	baseDir string
+	baseDir    string
+	originalWd string
+	workingDir string
 }
 
 // NewLoader creates and returns a loader that reads configuration from the
@@ -48,16 +50,43 @@ func NewLoader(fs afero.Afero, originalWd string) (*Loader, error) {
 		return nil, fmt.Errorf("failed to determine base dir: %s", err)
 	}
 
+	return newLoader(fs, baseDir, originalWd, "")
+}
+
+// NewLoaderInDir is the same as NewLoader, but reads configuration from the given
+// directory instead of the current directory. A relative directory is resolved
+// against the original working dir.
+//
+// Unlike changing the current directory, this does not affect the whole process,
+// so multiple loaders for different directories can be used at the same time.
+func NewLoaderInDir(fs afero.Afero, originalWd string, dir string) (*Loader, error) {
+	log.Printf("[INFO] Initialize new loader in %s", dir)
+
+	wd := dir
+	if !filepath.IsAbs(wd) {
+		wd = filepath.Join(originalWd, dir)
+	}
+	baseDir, err := filepath.Rel(originalWd, wd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine base dir: %s", err)
+	}
+
+	return newLoader(afero.Afero{Fs: NewWorkingDirFs(fs.Fs, wd)}, baseDir, originalWd, wd)
+}
+
+func newLoader(fs afero.Afero, baseDir string, originalWd string, workingDir string) (*Loader, error) {
 	ret := &Loader{
 		parser: NewParser(fs),
 		modules: moduleMgr{
 			fs:       fs,
 			manifest: moduleManifest{},
 		},
-		baseDir: baseDir,
+		baseDir:    baseDir,
+		originalWd: originalWd,
+		workingDir: workingDir,
 	}
 
-	err = ret.modules.readModuleManifest()
+	err := ret.modules.readModuleManifest()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read module manifest: %s", err)
 	}
@@ -175,7 +204,7 @@ func (l *Loader) LoadValuesFiles(dir string, files ...string) ([]InputValues, hc
 		return nil, diags
 	}
 	defaultVarsFile := filepath.Join(dir, defaultVarsFilename)
-	if _, err := os.Stat(defaultVarsFile); err == nil {
+	if l.parser.Exists(defaultVarsFile) {
 		autoLoadFiles = append([]string{defaultVarsFile}, autoLoadFiles...)
 	}
 
@@ -227,3 +256,13 @@ func (l *Loader) Sources() map[string][]byte {
 func (l *Loader) Files() map[string]*hcl.File {
 	return l.parser.Files()
 }
+
+// ContextMeta returns the metadata used to evaluate expressions in the loaded configuration,
+// such as the current workspace and the working directory.
+func (l *Loader) ContextMeta() *ContextMeta {
+	return &ContextMeta{
+		Env:                WorkspaceFromFs(l.parser.fs),
+		OriginalWorkingDir: l.originalWd,
+		WorkingDir:         l.workingDir,
+	}
+}
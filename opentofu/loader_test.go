@@ -584,6 +584,111 @@ func TestLoadConfigDirFiles_loader_withArgDir(t *testing.T) {
 	})
 }
 
+func TestLoadConfig_inDir(t *testing.T) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The current dir is not changed, and the base dir is the current dir
+	loader, err := NewLoaderInDir(afero.Afero{Fs: afero.NewOsFs()}, currentDir, filepath.Join("test-fixtures", "without_module_manifest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, diags := loader.LoadConfig(".", CallLocalModule)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	// root
+	if config.Module.SourceDir != "." {
+		t.Fatalf("root module path: want=%s, got=%s", ".", config.Module.SourceDir)
+	}
+	// module.instance
+	testChildModule(t, config, "instance", "ec2")
+
+	want := []string{
+		filepath.Join("test-fixtures", "without_module_manifest", "ec2", "main.tf"),
+		filepath.Join("test-fixtures", "without_module_manifest", "module.tf"),
+	}
+	loadedFiles := []string{}
+	for name := range loader.Files() {
+		loadedFiles = append(loadedFiles, name)
+	}
+	opt := cmpopts.SortSlices(func(x, y string) bool { return x < y })
+	if diff := cmp.Diff(want, loadedFiles, opt); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLoadValuesFiles_inDir(t *testing.T) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loader, err := NewLoaderInDir(afero.Afero{Fs: afero.NewOsFs()}, currentDir, filepath.Join("test-fixtures", "values_files"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Files passed manually are relative to the working directory of the loader.
+	ret, diags := loader.LoadValuesFiles(".", "cli1.tfvars")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	expected := []InputValues{
+		{
+			"default": {
+				Value: cty.StringVal("terraform.tfvars"),
+			},
+		},
+		{
+			"auto1": {
+				Value: cty.StringVal("auto1.auto.tfvars"),
+			},
+		},
+		{
+			"auto2": {
+				Value: cty.StringVal("auto2.auto.tfvars"),
+			},
+		},
+		{
+			"cli1": {
+				Value: cty.StringVal("cli1.tfvars"),
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, ret) {
+		t.Fatalf("Unexpected input values are received: expected=%#v actual=%#v", expected, ret)
+	}
+}
+
+func TestLoaderContextMeta(t *testing.T) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TF_WORKSPACE", "")
+	t.Setenv("TF_DATA_DIR", "")
+
+	dir := filepath.Join(currentDir, "test-fixtures", "workspace")
+	loader, err := NewLoaderInDir(afero.Afero{Fs: afero.NewOsFs()}, currentDir, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ContextMeta{
+		Env:                "staging",
+		OriginalWorkingDir: currentDir,
+		WorkingDir:         dir,
+	}
+	if diff := cmp.Diff(want, loader.ContextMeta()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func withinFixtureDir(t *testing.T, dir string, test func(string)) {
 	t.Helper()
 
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+
+	"github.com/spf13/afero"
 )
 
 func dataDir() string {
@@ -20,12 +22,18 @@ func dataDir() string {
 }
 
 func Workspace() string {
+	return WorkspaceFromFs(afero.Afero{Fs: afero.NewOsFs()})
+}
+
+// WorkspaceFromFs is like Workspace, but reads the environment file from the filesystem.
+// It is useful for a filesystem created by NewWorkingDirFs.
+func WorkspaceFromFs(fs afero.Afero) string {
 	if envVar := os.Getenv("TF_WORKSPACE"); envVar != "" {
 		log.Printf("[INFO] TF_WORKSPACE environment variable found: %s", envVar)
 		return envVar
 	}
 
-	envData, _ := os.ReadFile(filepath.Join(dataDir(), "environment"))
+	envData, _ := fs.ReadFile(filepath.Join(dataDir(), "environment"))
 	current := string(bytes.TrimSpace(envData))
 	if current != "" {
 		log.Printf("[INFO] environment file found: %s", current)
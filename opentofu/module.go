@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/arsiba/tofulint/opentofu/addrs"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	hcljson "github.com/hashicorp/hcl/v2/json"
@@ -28,10 +29,22 @@ type Module struct {
 	// assign a suitable value to this attribute before using it for other
 	// purposes. It should be treated as immutable by all consumers of Module
 	// values.
-	Resources   map[string]map[string]*Resource
-	Variables   map[string]*Variable
-	Locals      map[string]*Local
-	ModuleCalls map[string]*ModuleCall
+	Resources map[string]map[string]*Resource
+	// DataResources are kept apart from Resources, as a managed resource and
+	// a data source can share the same type and name.
+	DataResources map[string]map[string]*Resource
+	Variables     map[string]*Variable
+	Locals        map[string]*Local
+	Outputs       map[string]*Output
+	ModuleCalls   map[string]*ModuleCall
+
+	ProviderConfigs map[string]*Provider
+	// Terraform is a list of "terraform" blocks, which can be declared in multiple files.
+	Terraform []*Terraform
+	Moved     []*Moved
+	Import    []*Import
+	Removed   []*Removed
+	Checks    map[string]*Check
 
 	SourceDir string
 
@@ -45,10 +58,19 @@ type Module struct {
 
 func NewEmptyModule() *Module {
 	return &Module{
-		Resources:   map[string]map[string]*Resource{},
-		Variables:   map[string]*Variable{},
-		Locals:      map[string]*Local{},
-		ModuleCalls: map[string]*ModuleCall{},
+		Resources:     map[string]map[string]*Resource{},
+		DataResources: map[string]map[string]*Resource{},
+		Variables:     map[string]*Variable{},
+		Locals:        map[string]*Local{},
+		Outputs:       map[string]*Output{},
+		ModuleCalls:   map[string]*ModuleCall{},
+
+		ProviderConfigs: map[string]*Provider{},
+		Terraform:       []*Terraform{},
+		Moved:           []*Moved{},
+		Import:          []*Import{},
+		Removed:         []*Removed{},
+		Checks:          map[string]*Check{},
 
 		SourceDir: "",
 
@@ -67,14 +89,14 @@ func (m *Module) build() hcl.Diagnostics {
 		return diags
 	}
 
+	// Blocks without names are collected from scratch when rebuilding
+	m.Terraform = []*Terraform{}
+	m.Moved = []*Moved{}
+	m.Import = []*Import{}
+	m.Removed = []*Removed{}
+
 	for _, block := range body.Blocks {
 		switch block.Type {
-		case "resource":
-			r := decodeResourceBlock(block)
-			if _, exists := m.Resources[r.Type]; !exists {
-				m.Resources[r.Type] = map[string]*Resource{}
-			}
-			m.Resources[r.Type][r.Name] = r
 		case "variable":
 			v, valDiags := decodeVairableBlock(block)
 			diags = diags.Extend(valDiags)
@@ -88,12 +110,115 @@ func (m *Module) build() hcl.Diagnostics {
 			for _, local := range locals {
 				m.Locals[local.Name] = local
 			}
+		case "output":
+			o, outputDiags := decodeOutputBlock(block)
+			diags = diags.Extend(outputDiags)
+			m.Outputs[o.Name] = o
+		case "provider":
+			p, providerDiags := decodeProviderBlock(block)
+			diags = diags.Extend(providerDiags)
+			m.ProviderConfigs[p.moduleUniqueKey()] = p
+		case "terraform":
+			t, terraformDiags := decodeTerraformBlock(block)
+			diags = diags.Extend(terraformDiags)
+			m.Terraform = append(m.Terraform, t)
+		case "moved":
+			m.Moved = append(m.Moved, decodeMovedBlock(block))
+		case "import":
+			m.Import = append(m.Import, decodeImportBlock(block))
+		case "removed":
+			m.Removed = append(m.Removed, decodeRemovedBlock(block))
+		case "check":
+			c := decodeCheckBlock(block)
+			m.Checks[c.Name] = c
 		}
 	}
 
+	diags = diags.Extend(m.buildResources())
+
 	return diags
 }
 
+// buildResources decodes "resource" and "data" blocks.
+// Unlike other blocks, resources retain their bodies so that the evaluator
+// can resolve references to their attributes, so they are decoded from
+// the files directly rather than from the body content.
+func (m *Module) buildResources() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	// Resources are collected from scratch, so that removed or renamed resources do not remain after rebuilding
+	m.Resources = map[string]map[string]*Resource{}
+	m.DataResources = map[string]map[string]*Resource{}
+
+	for _, f := range m.primaries {
+		content, _, d := f.Body.PartialContent(resourceBlocksSchema)
+		diags = diags.Extend(d)
+
+		for _, block := range content.Blocks {
+			r, d := decodeResourceBlock(block, resourceMode(block.Type))
+			diags = diags.Extend(d)
+
+			resources := m.Resources
+			if r.Mode == addrs.DataResourceMode {
+				resources = m.DataResources
+			}
+			if _, exists := resources[r.Type]; !exists {
+				resources[r.Type] = map[string]*Resource{}
+			}
+			resources[r.Type][r.Name] = r
+		}
+	}
+
+	// Overrides are applied in order by filename, same as primary files.
+	for _, name := range m.overrideFilenames {
+		f, exists := m.overrides[name]
+		if !exists {
+			continue
+		}
+		content, _, d := f.Body.PartialContent(resourceBlocksSchema)
+		diags = diags.Extend(d)
+
+		for _, block := range content.Blocks {
+			override, d := decodeResourceBlock(block, resourceMode(block.Type))
+			diags = diags.Extend(d)
+
+			r := m.ResourceByAddr(override.Addr())
+			if r == nil {
+				continue
+			}
+			r.overrides = append(r.overrides, override.Config)
+			if override.Count != nil {
+				r.Count = override.Count
+			}
+			if override.ForEach != nil {
+				r.ForEach = override.ForEach
+			}
+		}
+	}
+
+	return diags
+}
+
+// ResourceByAddr returns the configuration for the resource with the given
+// address, or nil if there is no such resource.
+func (m *Module) ResourceByAddr(addr addrs.Resource) *Resource {
+	switch addr.Mode {
+	case addrs.ManagedResourceMode:
+		return m.Resources[addr.Type][addr.Name]
+	case addrs.DataResourceMode:
+		return m.DataResources[addr.Type][addr.Name]
+	default:
+		return nil
+	}
+}
+
+func resourceMode(blockType string) addrs.ResourceMode {
+	if blockType == "data" {
+		return addrs.DataResourceMode
+	}
+	return addrs.ManagedResourceMode
+}
+
 // Rebuild rebuilds the module from the passed sources.
 // The main purpose of this is to apply autofixes in the module.
 func (m *Module) Rebuild(sources map[string][]byte) hcl.Diagnostics {
@@ -194,10 +319,6 @@ func overrideBlocks(primaries, overrides hclext.Blocks) hclext.Blocks {
 
 var moduleSchema = &hclext.BodySchema{
 	Blocks: []hclext.BlockSchema{
-		{
-			Type:       "resource",
-			LabelNames: []string{"type", "name"},
-		},
 		{
 			Type:       "variable",
 			LabelNames: []string{"name"},
@@ -212,5 +333,36 @@ var moduleSchema = &hclext.BodySchema{
 			Type: "locals",
 			Body: localBlockSchema,
 		},
+		{
+			Type:       "output",
+			LabelNames: []string{"name"},
+			Body:       outputBlockSchema,
+		},
+		{
+			Type:       "provider",
+			LabelNames: []string{"name"},
+			Body:       providerBlockSchema,
+		},
+		{
+			Type: "terraform",
+			Body: terraformBlockSchema,
+		},
+		{
+			Type: "moved",
+			Body: movedBlockSchema,
+		},
+		{
+			Type: "import",
+			Body: importBlockSchema,
+		},
+		{
+			Type: "removed",
+			Body: removedBlockSchema,
+		},
+		{
+			Type:       "check",
+			LabelNames: []string{"name"},
+			Body:       checkBlockSchema,
+		},
 	},
 }
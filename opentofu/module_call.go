@@ -21,6 +21,9 @@ type ModuleCall struct {
 	SourceAddr    addrs.ModuleSource
 	SourceAddrRaw string
 
+	Count   hcl.Expression
+	ForEach hcl.Expression
+
 	DeclRange hcl.Range
 }
 
@@ -50,6 +53,13 @@ func decodeModuleBlock(block *hclext.Block) (*ModuleCall, hcl.Diagnostics) {
 		}
 	}
 
+	if attr, exists := block.Body.Attributes["count"]; exists {
+		mc.Count = attr.Expr
+	}
+	if attr, exists := block.Body.Attributes["for_each"]; exists {
+		mc.ForEach = attr.Expr
+	}
+
 	return mc, diags
 }
 
@@ -58,6 +68,12 @@ var moduleBlockSchema = &hclext.BodySchema{
 		{
 			Name: "source",
 		},
+		{
+			Name: "count",
+		},
+		{
+			Name: "for_each",
+		},
 	},
 }
 
@@ -0,0 +1,192 @@
+package opentofu
+
+import (
+	"os"
+	"testing"
+
+	"github.com/arsiba/tofulint/opentofu/addrs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func TestModuleBuild(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+terraform {
+  required_version = ">= 1.6"
+
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      version               = "~> 5.0"
+      configuration_aliases = [aws.west]
+    }
+    google = "~> 4.0"
+  }
+
+  backend "s3" {
+    bucket = "state"
+  }
+}
+
+variable "env" {
+  validation {
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "env must be dev or prod"
+  }
+
+  validation {
+    condition     = length(var.env) > 0
+    error_message = "env must not be empty"
+  }
+}
+
+provider "aws" {}
+
+provider "aws" {
+  alias = "east"
+}
+
+resource "aws_instance" "main" {
+  count = 2
+}
+
+data "aws_ami" "main" {
+  for_each = toset(["a", "b"])
+}
+
+output "id" {
+  value     = aws_instance.main[0].id
+  sensitive = true
+}
+
+moved {
+  from = aws_instance.old
+  to   = aws_instance.main
+}
+
+import {
+  to = aws_instance.main[0]
+  id = "i-12345678"
+}
+
+removed {
+  from = aws_instance.removed
+}
+
+check "health" {
+  data "http" "main" {
+    url = "https://example.com"
+  }
+
+  assert {
+    condition     = data.http.main.status_code == 200
+    error_message = "unhealthy"
+  }
+}`,
+		"main_override.tf": `
+resource "aws_instance" "main" {
+  count = 3
+}`,
+	}
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	for name, src := range files {
+		if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	parser := NewParser(fs)
+	mod, diags := parser.LoadConfigDir(".", ".")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	opts := cmp.Options{
+		cmpopts.IgnoreTypes(hcl.Range{}),
+		cmpopts.IgnoreInterfaces(struct{ hcl.Expression }{}),
+		cmpopts.IgnoreInterfaces(struct{ hcl.Body }{}),
+		cmpopts.IgnoreUnexported(Resource{}),
+	}
+
+	wantResources := map[string]map[string]*Resource{
+		"aws_instance": {"main": {Mode: addrs.ManagedResourceMode, Type: "aws_instance", Name: "main"}},
+	}
+	if diff := cmp.Diff(wantResources, mod.Resources, opts); diff != "" {
+		t.Errorf("Resources: %s", diff)
+	}
+	wantDataResources := map[string]map[string]*Resource{
+		"aws_ami": {"main": {Mode: addrs.DataResourceMode, Type: "aws_ami", Name: "main"}},
+	}
+	if diff := cmp.Diff(wantDataResources, mod.DataResources, opts); diff != "" {
+		t.Errorf("DataResources: %s", diff)
+	}
+	count, diags := mod.Resources["aws_instance"]["main"].Count.Value(nil)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if count.AsBigFloat().String() != "3" {
+		t.Errorf("count is not overridden: %s", count.GoString())
+	}
+	if mod.DataResources["aws_ami"]["main"].ForEach == nil {
+		t.Error("for_each is not decoded")
+	}
+
+	if validations := mod.Variables["env"].Validations; len(validations) != 2 || validations[0].Condition == nil || validations[0].ErrorMessage == nil {
+		t.Errorf("Validations: %#v", validations)
+	}
+
+	wantOutputs := map[string]*Output{"id": {Name: "id", Sensitive: true}}
+	if diff := cmp.Diff(wantOutputs, mod.Outputs, opts); diff != "" {
+		t.Errorf("Outputs: %s", diff)
+	}
+
+	wantProviders := map[string]*Provider{
+		"aws":      {Name: "aws"},
+		"aws.east": {Name: "aws", Alias: "east"},
+	}
+	if diff := cmp.Diff(wantProviders, mod.ProviderConfigs, opts); diff != "" {
+		t.Errorf("ProviderConfigs: %s", diff)
+	}
+
+	wantTerraform := []*Terraform{
+		{
+			RequiredProviders: map[string]*RequiredProvider{
+				"aws":    {Name: "aws", Source: "hashicorp/aws", Version: "~> 5.0"},
+				"google": {Name: "google", Version: "~> 4.0"},
+			},
+			Backend: &Backend{Type: "s3"},
+		},
+	}
+	if diff := cmp.Diff(wantTerraform, mod.Terraform, opts); diff != "" {
+		t.Errorf("Terraform: %s", diff)
+	}
+
+	if len(mod.Moved) != 1 || mod.Moved[0].From == nil || mod.Moved[0].To == nil {
+		t.Errorf("Moved: %#v", mod.Moved)
+	}
+	if len(mod.Import) != 1 || mod.Import[0].To == nil || mod.Import[0].ID == nil {
+		t.Errorf("Import: %#v", mod.Import)
+	}
+	if len(mod.Removed) != 1 || mod.Removed[0].From == nil {
+		t.Errorf("Removed: %#v", mod.Removed)
+	}
+
+	wantChecks := map[string]*Check{"health": {Name: "health", Asserts: []*CheckRule{{}}}}
+	if diff := cmp.Diff(wantChecks, mod.Checks, opts); diff != "" {
+		t.Errorf("Checks: %s", diff)
+	}
+	if mod.Checks["health"].Asserts[0].Condition == nil {
+		t.Error("condition is not decoded")
+	}
+
+	// Blocks without names must not be duplicated on rebuild
+	if diags := mod.Rebuild(map[string][]byte{"main.tf": []byte(files["main.tf"])}); diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if len(mod.Terraform) != 1 || len(mod.Moved) != 1 || len(mod.Import) != 1 || len(mod.Removed) != 1 {
+		t.Errorf("blocks are duplicated on rebuild")
+	}
+}
@@ -0,0 +1,41 @@
+package opentofu
+
+import (
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Moved represents a "moved" block in a module or file.
+// The addresses are kept as expressions because they are not evaluated.
+type Moved struct {
+	From hcl.Expression
+	To   hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+func decodeMovedBlock(block *hclext.Block) *Moved {
+	m := &Moved{
+		DeclRange: block.DefRange,
+	}
+
+	if attr, exists := block.Body.Attributes["from"]; exists {
+		m.From = attr.Expr
+	}
+	if attr, exists := block.Body.Attributes["to"]; exists {
+		m.To = attr.Expr
+	}
+
+	return m
+}
+
+var movedBlockSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{
+			Name: "from",
+		},
+		{
+			Name: "to",
+		},
+	},
+}
@@ -0,0 +1,46 @@
+package opentofu
+
+import (
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// Output represents an "output" block in a module or file.
+type Output struct {
+	Name      string
+	Expr      hcl.Expression
+	Sensitive bool
+
+	DeclRange hcl.Range
+}
+
+func decodeOutputBlock(block *hclext.Block) (*Output, hcl.Diagnostics) {
+	o := &Output{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+	diags := hcl.Diagnostics{}
+
+	if attr, exists := block.Body.Attributes["value"]; exists {
+		o.Expr = attr.Expr
+	}
+
+	if attr, exists := block.Body.Attributes["sensitive"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Sensitive)
+		diags = diags.Extend(valDiags)
+	}
+
+	return o, diags
+}
+
+var outputBlockSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{
+			Name: "value",
+		},
+		{
+			Name: "sensitive",
+		},
+	},
+}
@@ -0,0 +1,49 @@
+package opentofu
+
+import (
+	"fmt"
+
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// Provider represents a "provider" block in a module or file.
+type Provider struct {
+	Name  string
+	Alias string
+
+	DeclRange hcl.Range
+}
+
+func decodeProviderBlock(block *hclext.Block) (*Provider, hcl.Diagnostics) {
+	p := &Provider{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+	diags := hcl.Diagnostics{}
+
+	if attr, exists := block.Body.Attributes["alias"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &p.Alias)
+		diags = diags.Extend(valDiags)
+	}
+
+	return p, diags
+}
+
+// moduleUniqueKey returns a key for the provider configuration that is unique in the module.
+// The key is the provider name, followed by the alias if set.
+func (p *Provider) moduleUniqueKey() string {
+	if p.Alias != "" {
+		return fmt.Sprintf("%s.%s", p.Name, p.Alias)
+	}
+	return p.Name
+}
+
+var providerBlockSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{
+			Name: "alias",
+		},
+	},
+}
@@ -0,0 +1,33 @@
+package opentofu
+
+import (
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Removed represents a "removed" block in a module or file.
+type Removed struct {
+	From hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+func decodeRemovedBlock(block *hclext.Block) *Removed {
+	r := &Removed{
+		DeclRange: block.DefRange,
+	}
+
+	if attr, exists := block.Body.Attributes["from"]; exists {
+		r.From = attr.Expr
+	}
+
+	return r
+}
+
+var removedBlockSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{
+			Name: "from",
+		},
+	},
+}
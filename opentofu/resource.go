@@ -6,26 +6,98 @@
 package opentofu
 
 import (
+	"github.com/arsiba/tofulint/opentofu/addrs"
 	"github.com/hashicorp/hcl/v2"
-	"github.com/arsiba/tofulint-plugin-sdk/hclext"
 )
 
 // Resource represents a "resource" or "data" block in a module or file.
 type Resource struct {
+	Mode addrs.ResourceMode
 	Name string
 	Type string
 
+	// Config is the body of the block in the primary file.
+	// Bodies in override files are kept separately. Use Attribute to get
+	// an attribute with overrides applied.
+	Config hcl.Body
+
+	Count   hcl.Expression
+	ForEach hcl.Expression
+
 	DeclRange hcl.Range
 	TypeRange hcl.Range
+
+	overrides []hcl.Body
 }
 
-func decodeResourceBlock(block *hclext.Block) *Resource {
+func decodeResourceBlock(block *hcl.Block, mode addrs.ResourceMode) (*Resource, hcl.Diagnostics) {
 	r := &Resource{
+		Mode:      mode,
 		Type:      block.Labels[0],
 		Name:      block.Labels[1],
+		Config:    block.Body,
 		DeclRange: block.DefRange,
 		TypeRange: block.LabelRanges[0],
 	}
 
-	return r
+	content, _, diags := block.Body.PartialContent(resourceMetaArgsSchema)
+	if attr, exists := content.Attributes["count"]; exists {
+		r.Count = attr.Expr
+	}
+	if attr, exists := content.Attributes["for_each"]; exists {
+		r.ForEach = attr.Expr
+	}
+
+	return r, diags
+}
+
+// Addr returns a resource address for the receiver.
+func (r *Resource) Addr() addrs.Resource {
+	return addrs.Resource{
+		Mode: r.Mode,
+		Type: r.Type,
+		Name: r.Name,
+	}
+}
+
+// Attribute returns the attribute with the given name in the resource body.
+// If the attribute is also set in override files, the last one takes precedence.
+// It returns nil if the attribute is not set.
+func (r *Resource) Attribute(name string) (*hcl.Attribute, hcl.Diagnostics) {
+	var ret *hcl.Attribute
+	var diags hcl.Diagnostics
+
+	schema := &hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: name}}}
+	for _, body := range append([]hcl.Body{r.Config}, r.overrides...) {
+		content, _, d := body.PartialContent(schema)
+		diags = diags.Extend(d)
+		if attr, exists := content.Attributes[name]; exists {
+			ret = attr
+		}
+	}
+	return ret, diags
+}
+
+// resourceMetaArgs is a list of meta-arguments that can be set in resource and data blocks.
+// These are not attributes of the resource object.
+var resourceMetaArgs = []string{"count", "for_each", "provider", "depends_on"}
+
+var resourceMetaArgsSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "count"},
+		{Name: "for_each"},
+	},
+}
+
+var resourceBlocksSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "resource",
+			LabelNames: []string{"type", "name"},
+		},
+		{
+			Type:       "data",
+			LabelNames: []string{"type", "name"},
+		},
+	},
 }
@@ -0,0 +1,117 @@
+package opentofu
+
+import (
+	"github.com/arsiba/tofulint-plugin-sdk/hclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// Terraform represents a "terraform" block in a module or file.
+type Terraform struct {
+	RequiredVersion   hcl.Expression
+	RequiredProviders map[string]*RequiredProvider
+	Backend           *Backend
+
+	DeclRange hcl.Range
+}
+
+// RequiredProvider represents an entry in a "required_providers" block.
+type RequiredProvider struct {
+	Name    string
+	Source  string
+	Version string
+
+	DeclRange hcl.Range
+}
+
+// Backend represents a "backend" block in a "terraform" block.
+type Backend struct {
+	Type string
+
+	DeclRange hcl.Range
+}
+
+func decodeTerraformBlock(block *hclext.Block) (*Terraform, hcl.Diagnostics) {
+	t := &Terraform{
+		RequiredProviders: map[string]*RequiredProvider{},
+		DeclRange:         block.DefRange,
+	}
+	diags := hcl.Diagnostics{}
+
+	if attr, exists := block.Body.Attributes["required_version"]; exists {
+		t.RequiredVersion = attr.Expr
+	}
+
+	for _, inner := range block.Body.Blocks {
+		switch inner.Type {
+		case "required_providers":
+			for name, attr := range inner.Body.Attributes {
+				rp, rpDiags := decodeRequiredProvider(attr)
+				diags = diags.Extend(rpDiags)
+				t.RequiredProviders[name] = rp
+			}
+		case "backend":
+			t.Backend = &Backend{
+				Type:      inner.Labels[0],
+				DeclRange: inner.DefRange,
+			}
+		}
+	}
+
+	return t, diags
+}
+
+// decodeRequiredProvider decodes an entry in a "required_providers" block.
+// The entry is either an object with "source" and "version", or a version string
+// in the legacy syntax. Other attributes such as "configuration_aliases" are ignored.
+func decodeRequiredProvider(attr *hclext.Attribute) (*RequiredProvider, hcl.Diagnostics) {
+	rp := &RequiredProvider{
+		Name:      attr.Name,
+		DeclRange: attr.Range,
+	}
+	diags := hcl.Diagnostics{}
+
+	pairs, mapDiags := hcl.ExprMap(attr.Expr)
+	if mapDiags.HasErrors() {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &rp.Version)
+		diags = diags.Extend(valDiags)
+		return rp, diags
+	}
+
+	for _, pair := range pairs {
+		var key string
+		if keyDiags := gohcl.DecodeExpression(pair.Key, nil, &key); keyDiags.HasErrors() {
+			diags = diags.Extend(keyDiags)
+			continue
+		}
+
+		switch key {
+		case "source":
+			valDiags := gohcl.DecodeExpression(pair.Value, nil, &rp.Source)
+			diags = diags.Extend(valDiags)
+		case "version":
+			valDiags := gohcl.DecodeExpression(pair.Value, nil, &rp.Version)
+			diags = diags.Extend(valDiags)
+		}
+	}
+
+	return rp, diags
+}
+
+var terraformBlockSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{
+			Name: "required_version",
+		},
+	},
+	Blocks: []hclext.BlockSchema{
+		{
+			Type: "required_providers",
+			Body: &hclext.BodySchema{Mode: hclext.SchemaJustAttributesMode},
+		},
+		{
+			Type:       "backend",
+			LabelNames: []string{"type"},
+		},
+	},
+}
@@ -16,6 +16,11 @@ type expandBody struct {
 	dynamicIteration *dynamicIteration // non-nil if we're nested inside a "dynamic" block
 	metaArgIteration *metaArgIteration // non-nil if we're nested inside a block with meta-arguments
 
+	// If true, blocks whose count/for_each is unknown are expanded to a representative instance.
+	representatives bool
+	// The unknown count/for_each attribute if this is the body of a representative instance.
+	representativeOf *hcl.Attribute
+
 	// These are used with PartialContent to produce a "remaining items"
 	// body to return. They are nil on all bodies fresh out of the transformer.
 	//
@@ -36,6 +41,7 @@ func (b *expandBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diag
 	diags = append(diags, blockDiags...)
 	attrs, attrDiags := b.prepareAttributes(rawContent.Attributes)
 	diags = append(diags, attrDiags...)
+	attrs = b.markRepresentative(schema, attrs)
 
 	content := &hcl.BodyContent{
 		Attributes:       attrs,
@@ -56,6 +62,7 @@ func (b *expandBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, h
 	diags = append(diags, blockDiags...)
 	attrs, attrDiags := b.prepareAttributes(rawContent.Attributes)
 	diags = append(diags, attrDiags...)
+	attrs = b.markRepresentative(schema, attrs)
 
 	content := &hcl.BodyContent{
 		Attributes:       attrs,
@@ -68,6 +75,8 @@ func (b *expandBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, h
 		ctx:              b.ctx,
 		dynamicIteration: b.dynamicIteration,
 		metaArgIteration: b.metaArgIteration,
+		representatives:  b.representatives,
+		representativeOf: b.representativeOf,
 		hiddenAttrs:      make(map[string]struct{}),
 		hiddenBlocks:     make(map[string]hcl.BlockHeaderSchema),
 	}
@@ -168,6 +177,31 @@ func (b *expandBody) prepareAttributes(rawAttrs hcl.Attributes) (hcl.Attributes,
 	return attrs, diags
 }
 
+// markRepresentative adds a synthetic attribute that marks the body as a representative instance
+// if the attribute is requested by the schema. The attribute value is always true, and its range
+// is the range of the unknown count/for_each.
+func (b *expandBody) markRepresentative(schema *hcl.BodySchema, attrs hcl.Attributes) hcl.Attributes {
+	if b.representativeOf == nil {
+		return attrs
+	}
+	if _, hidden := b.hiddenAttrs[RepresentativeAttributeName]; hidden {
+		return attrs
+	}
+	for _, attrS := range schema.Attributes {
+		if attrS.Name != RepresentativeAttributeName {
+			continue
+		}
+		// The representative body always has an iteration, so attrs is not shared with the original body.
+		attrs[RepresentativeAttributeName] = &hcl.Attribute{
+			Name:      RepresentativeAttributeName,
+			Expr:      hclext.BindValue(cty.True, b.representativeOf.Expr),
+			Range:     b.representativeOf.Range,
+			NameRange: b.representativeOf.NameRange,
+		}
+	}
+	return attrs
+}
+
 func (b *expandBody) expandBlocks(schema *hcl.BodySchema, rawBlocks hcl.Blocks, partial bool) (hcl.Blocks, hcl.Diagnostics) {
 	var blocks hcl.Blocks
 	var diags hcl.Diagnostics
@@ -268,8 +302,17 @@ func (b *expandBody) expandMetaArgBlock(schema *hcl.BodySchema, rawBlock *hcl.Bl
 
 	if spec.countSet {
 		if !spec.countVal.IsKnown() {
-			// If count is unknown, no blocks are returned
-			return hcl.Blocks{}, diags
+			if !b.representatives {
+				// If count is unknown, no blocks are returned
+				return hcl.Blocks{}, diags
+			}
+			// Otherwise, a representative instance with an unknown index is returned
+			i := MakeCountIteration(cty.UnknownVal(cty.Number))
+
+			expandedBlock := *rawBlock // shallow copy
+			expandedBlock.Body = b.expandChild(rawBlock.Body, b.dynamicIteration, i)
+			expandedBlock.Body.(*expandBody).representativeOf = spec.countAttr
+			return hcl.Blocks{&expandedBlock}, diags
 		}
 
 		var blocks hcl.Blocks
@@ -289,8 +332,17 @@ func (b *expandBody) expandMetaArgBlock(schema *hcl.BodySchema, rawBlock *hcl.Bl
 
 	if spec.forEachSet {
 		if !spec.forEachVal.IsKnown() {
-			// If for_each is unknown, no blocks are returned
-			return hcl.Blocks{}, diags
+			if !b.representatives {
+				// If for_each is unknown, no blocks are returned
+				return hcl.Blocks{}, diags
+			}
+			// Otherwise, a representative instance with an unknown key and value is returned
+			i := MakeForEachIteration(unknownForEachElement(spec.forEachVal.Type()))
+
+			expandedBlock := *rawBlock // shallow copy
+			expandedBlock.Body = b.expandChild(rawBlock.Body, b.dynamicIteration, i)
+			expandedBlock.Body.(*expandBody).representativeOf = spec.forEachAttr
+			return hcl.Blocks{&expandedBlock}, diags
 		}
 
 		var blocks hcl.Blocks
@@ -326,6 +378,7 @@ func (b *expandBody) expandChild(child hcl.Body, i *dynamicIteration, mi *metaAr
 	ret := Expand(child, chiCtx)
 	ret.(*expandBody).dynamicIteration = i
 	ret.(*expandBody).metaArgIteration = mi
+	ret.(*expandBody).representatives = b.representatives
 	return ret
 }
 
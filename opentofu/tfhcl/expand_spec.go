@@ -219,12 +219,14 @@ func (s *expandDynamicSpec) newBlock(i *dynamicIteration, ctx *hcl.EvalContext)
 }
 
 type expandMetaArgSpec struct {
-	rawBlock   *hcl.Block
-	countSet   bool
-	countVal   cty.Value
-	countNum   int
-	forEachSet bool
-	forEachVal cty.Value
+	rawBlock    *hcl.Block
+	countSet    bool
+	countAttr   *hcl.Attribute
+	countVal    cty.Value
+	countNum    int
+	forEachSet  bool
+	forEachAttr *hcl.Attribute
+	forEachVal  cty.Value
 }
 
 func (b *expandBody) decodeMetaArgSpec(rawSpec *hcl.Block) (*expandMetaArgSpec, hcl.Diagnostics) {
@@ -241,6 +243,7 @@ func (b *expandBody) decodeMetaArgSpec(rawSpec *hcl.Block) (*expandMetaArgSpec,
 
 	if countAttr, exists := specContent.Attributes["count"]; exists {
 		spec.countSet = true
+		spec.countAttr = countAttr
 
 		countVal, countDiags := countAttr.Expr.Value(b.ctx)
 		diags = append(diags, countDiags...)
@@ -306,6 +309,7 @@ func (b *expandBody) decodeMetaArgSpec(rawSpec *hcl.Block) (*expandMetaArgSpec,
 
 	if eachAttr, exists := specContent.Attributes["for_each"]; exists {
 		spec.forEachSet = true
+		spec.forEachAttr = eachAttr
 
 		eachVal, eachDiags := eachAttr.Expr.Value(b.ctx)
 		diags = append(diags, eachDiags...)
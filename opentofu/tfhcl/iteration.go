@@ -80,6 +80,21 @@ func MakeForEachIteration(key, value cty.Value) *metaArgIteration {
 	}
 }
 
+// unknownForEachElement returns an unknown key and value of an element in the for_each value of the given type.
+func unknownForEachElement(ty cty.Type) (cty.Value, cty.Value) {
+	switch {
+	case ty.IsMapType():
+		return cty.UnknownVal(cty.String), cty.UnknownVal(ty.ElementType())
+	case ty.IsSetType():
+		// For sets, each.key is the same as each.value
+		return cty.UnknownVal(ty.ElementType()), cty.UnknownVal(ty.ElementType())
+	default:
+		// Object attributes can have different types, and the type of an unknown value may not be known.
+		// Keys are always strings in both cases.
+		return cty.UnknownVal(cty.String), cty.DynamicVal
+	}
+}
+
 func (i *metaArgIteration) EvalContext(base *hcl.EvalContext) *hcl.EvalContext {
 	new := base.NewChild()
 
@@ -7,6 +7,10 @@ package tfhcl
 
 import "github.com/hashicorp/hcl/v2"
 
+// RepresentativeAttributeName is the name of the synthetic attribute that marks
+// the body of a representative instance. See ExpandWithRepresentatives.
+const RepresentativeAttributeName = "tflint_representative"
+
 // Expand "dynamic" blocks and count/for_for_each meta-arguments resources
 // in the given body, returning a new body that has those blocks expanded.
 //
@@ -23,3 +27,18 @@ func Expand(body hcl.Body, ctx *hcl.EvalContext) hcl.Body {
 		ctx:      ctx,
 	}
 }
+
+// ExpandWithRepresentatives is like Expand, but resources and modules whose
+// count/for_each is unknown are expanded to a single representative instance
+// instead of no instances. In the representative instance, count.index,
+// each.key and each.value are unknown values of the appropriate type.
+//
+// If the schema of the representative instance requests RepresentativeAttributeName,
+// the body has the attribute with a value of true. Other instances don't have it.
+func ExpandWithRepresentatives(body hcl.Body, ctx *hcl.EvalContext) hcl.Body {
+	return &expandBody{
+		original:        body,
+		ctx:             ctx,
+		representatives: true,
+	}
+}
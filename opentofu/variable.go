@@ -25,6 +25,8 @@ type Variable struct {
 	ParsingMode VariableParsingMode
 	Sensitive   bool
 	Nullable    bool
+
+	Validations []*CheckRule
 }
 
 func decodeVairableBlock(block *hclext.Block) (*Variable, hcl.Diagnostics) {
@@ -88,6 +90,22 @@ func decodeVairableBlock(block *hclext.Block) (*Variable, hcl.Diagnostics) {
 		v.Default = val
 	}
 
+	for _, inner := range block.Body.Blocks {
+		if inner.Type != "validation" {
+			continue
+		}
+		rule := &CheckRule{
+			DeclRange: inner.DefRange,
+		}
+		if attr, exists := inner.Body.Attributes["condition"]; exists {
+			rule.Condition = attr.Expr
+		}
+		if attr, exists := inner.Body.Attributes["error_message"]; exists {
+			rule.ErrorMessage = attr.Expr
+		}
+		v.Validations = append(v.Validations, rule)
+	}
+
 	return v, diags
 }
 
@@ -233,4 +251,19 @@ var variableBlockSchema = &hclext.BodySchema{
 			Name: "nullable",
 		},
 	},
+	Blocks: []hclext.BlockSchema{
+		{
+			Type: "validation",
+			Body: &hclext.BodySchema{
+				Attributes: []hclext.AttributeSchema{
+					{
+						Name: "condition",
+					},
+					{
+						Name: "error_message",
+					},
+				},
+			},
+		},
+	},
 }
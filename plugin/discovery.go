@@ -133,6 +133,44 @@ func getPluginDir(cfg *tflint.Config) (string, error) {
 	return localPluginRoot, err
 }
 
+// ResolvePluginDir resolves the plugin directory of the passed config against
+// the given directory instead of the current directory.
+//
+// A relative `plugin_dir` and the local plugin directory (./.tflint.d/plugins)
+// are made absolute, so that plugins can be discovered for a working directory
+// without changing the current directory. The resolved path is set to `PluginDir`.
+func ResolvePluginDir(cfg *tflint.Config, dir string) error {
+	if cfg.PluginDir != "" {
+		pluginDir, err := homedir.Expand(cfg.PluginDir)
+		if err != nil {
+			return err
+		}
+		if !filepath.IsAbs(pluginDir) {
+			pluginDir = filepath.Join(dir, pluginDir)
+		}
+		cfg.PluginDir = pluginDir
+		return nil
+	}
+
+	if envDir := os.Getenv("TFLINT_PLUGIN_DIR"); envDir != "" {
+		if !filepath.IsAbs(envDir) {
+			cfg.PluginDir = filepath.Join(dir, envDir)
+		}
+		return nil
+	}
+
+	localDir := filepath.Join(dir, localPluginRoot)
+	_, err := os.Stat(localDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cfg.PluginDir = localDir
+	return nil
+}
+
 // findPluginPath returns the path of the existing plugin.
 // Only in the case of Windows, the pattern with the `.exe` is also considered,
 // and if it exists, the extension is added to the argument.
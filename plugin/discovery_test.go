@@ -522,3 +522,71 @@ func Test_FindPluginPath_withoutExtensionInWindows(t *testing.T) {
 		t.Fatalf("Failed: want=%s got=%s", expected, got)
 	}
 }
+
+func Test_ResolvePluginDir(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	localsDir := filepath.Join(cwd, "test-fixtures", "locals")
+
+	tests := []struct {
+		name   string
+		config *tflint.Config
+		env    string
+		dir    string
+		want   string
+	}{
+		{
+			name:   "relative plugin_dir",
+			config: &tflint.Config{PluginDir: "./plugins"},
+			dir:    "/root",
+			want:   filepath.Join("/root", "plugins"),
+		},
+		{
+			name:   "absolute plugin_dir",
+			config: &tflint.Config{PluginDir: "/plugins"},
+			dir:    "/root",
+			want:   "/plugins",
+		},
+		{
+			name:   "relative TFLINT_PLUGIN_DIR",
+			config: &tflint.Config{},
+			env:    "plugins",
+			dir:    "/root",
+			want:   filepath.Join("/root", "plugins"),
+		},
+		{
+			name:   "absolute TFLINT_PLUGIN_DIR",
+			config: &tflint.Config{},
+			env:    "/plugins",
+			dir:    "/root",
+			want:   "",
+		},
+		{
+			name:   "local plugin dir",
+			config: &tflint.Config{},
+			dir:    localsDir,
+			want:   filepath.Join(localsDir, ".tflint.d", "plugins"),
+		},
+		{
+			name:   "no local plugin dir",
+			config: &tflint.Config{},
+			dir:    filepath.Join(cwd, "test-fixtures", "no_plugins"),
+			want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("TFLINT_PLUGIN_DIR", test.env)
+
+			if err := ResolvePluginDir(test.config, test.dir); err != nil {
+				t.Fatal(err)
+			}
+			if test.config.PluginDir != test.want {
+				t.Errorf("got %s, want %s", test.config.PluginDir, test.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/arsiba/tofulint/opentofu"
+	"github.com/arsiba/tofulint/opentofu/tfhcl"
 	"github.com/arsiba/tofulint/tflint"
 	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
@@ -57,19 +58,62 @@ func (s *GRPCServer) GetModuleContent(bodyS *hclext.BodySchema, opts sdk.GetModu
 	}
 
 	// For performance, determine in advance whether the target resource exists.
-	if opts.Hint.ResourceType != "" {
-		if _, exists := module.Resources[opts.Hint.ResourceType]; !exists {
-			return &hclext.BodyContent{}, nil
-		}
+	if opts.Hint.ResourceType != "" && !hintedResourceExists(module, bodyS, opts.Hint.ResourceType) {
+		return &hclext.BodyContent{}, nil
 	}
 
 	if opts.ExpandMode == sdk.ExpandModeNone {
 		ctx = nil
+	} else if representativesRequested(bodyS) {
+		// Resources whose count/for_each is unknown are inspected as a representative instance
+		// only if the plugin opts in by requesting the marker attribute.
+		ctx = ctx.WithRepresentatives()
 	}
 
 	return module.PartialContent(bodyS, ctx)
 }
 
+// representativesRequested returns whether any block schema requests the attribute
+// that marks representative instances. This is how plugins opt in to representative instances,
+// as the expand mode and hint in the SDK protocol cannot be extended.
+func representativesRequested(schema *hclext.BodySchema) bool {
+	for _, block := range schema.Blocks {
+		if block.Body == nil {
+			continue
+		}
+		for _, attr := range block.Body.Attributes {
+			if attr.Name == tfhcl.RepresentativeAttributeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hintedResourceExists returns whether the module has resources or data sources of the hinted type
+// for the "resource" and "data" blocks in the schema. If the schema contains other attributes or blocks,
+// it always returns true because the hint does not apply to them.
+func hintedResourceExists(module *opentofu.Module, schema *hclext.BodySchema, resourceType string) bool {
+	if len(schema.Attributes) > 0 {
+		return true
+	}
+	for _, block := range schema.Blocks {
+		switch block.Type {
+		case "resource":
+			if _, exists := module.Resources[resourceType]; exists {
+				return true
+			}
+		case "data":
+			if _, exists := module.DataResources[resourceType]; exists {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
 // GetFile returns the hcl.File based on passed the file name.
 func (s *GRPCServer) GetFile(name string) (*hcl.File, error) {
 	// Considering that autofix has been applied, prioritize returning the value of runner.Files().
@@ -42,6 +42,9 @@ resource "aws_instance" "baz" {
 		for_each = toset(["foo"])
 		content {}
 	}
+}
+data "aws_ami" "main" {
+	owners = ["amazon"]
 }`})
 	rootRunner := tflint.TestRunner(t, map[string]string{"main.tf": `
 resource "aws_instance" "bar" {
@@ -168,6 +171,49 @@ resource "aws_instance" "bar" {
 				},
 			},
 		},
+		{
+			Name: "data source hint",
+			Args: func() (*hclext.BodySchema, sdk.GetModuleContentOption) {
+				return &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type:       "data",
+							LabelNames: []string{"type", "name"},
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "owners"}},
+							},
+						},
+					},
+				}, sdk.GetModuleContentOption{ModuleCtx: sdk.SelfModuleCtxType, Hint: sdk.GetModuleContentHint{ResourceType: "aws_ami"}}
+			},
+			Want: &hclext.BodyContent{
+				Blocks: hclext.Blocks{
+					{
+						Type:   "data",
+						Labels: []string{"aws_ami", "main"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{"owners": &hclext.Attribute{Name: "owners"}},
+							Blocks:     hclext.Blocks{},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "data source hint not found",
+			Args: func() (*hclext.BodySchema, sdk.GetModuleContentOption) {
+				return &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type:       "data",
+							LabelNames: []string{"type", "name"},
+							Body:       &hclext.BodySchema{},
+						},
+					},
+				}, sdk.GetModuleContentOption{ModuleCtx: sdk.SelfModuleCtxType, Hint: sdk.GetModuleContentHint{ResourceType: "aws_instance"}}
+			},
+			Want: &hclext.BodyContent{},
+		},
 	}
 
 	for _, test := range tests {
@@ -193,6 +239,147 @@ resource "aws_instance" "bar" {
 	}
 }
 
+func TestGetModuleContent_representatives(t *testing.T) {
+	runner := tflint.TestRunner(t, map[string]string{"main.tf": `
+variable "count" {
+	type = number
+}
+variable "names" {
+	type = set(string)
+}
+
+resource "aws_instance" "count" {
+	count         = var.count
+	instance_type = "t2.micro"
+}
+resource "aws_instance" "for_each" {
+	for_each      = var.names
+	instance_type = each.key
+}
+resource "aws_instance" "known" {
+	count         = 1
+	instance_type = "t3.nano"
+}`})
+
+	server := NewGRPCServer(runner, runner, runner.Files(), SDKVersion)
+
+	tests := []struct {
+		Name   string
+		Schema *hclext.BodySchema
+		Want   *hclext.BodyContent
+	}{
+		{
+			Name: "default",
+			Schema: &hclext.BodySchema{
+				Blocks: []hclext.BlockSchema{
+					{
+						Type:       "resource",
+						LabelNames: []string{"type", "name"},
+						Body:       &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "instance_type"}}},
+					},
+				},
+			},
+			Want: &hclext.BodyContent{
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "known"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{"instance_type": &hclext.Attribute{Name: "instance_type"}},
+							Blocks:     hclext.Blocks{},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "opt in to representatives",
+			Schema: &hclext.BodySchema{
+				Blocks: []hclext.BlockSchema{
+					{
+						Type:       "resource",
+						LabelNames: []string{"type", "name"},
+						Body: &hclext.BodySchema{
+							Attributes: []hclext.AttributeSchema{{Name: "instance_type"}, {Name: "tflint_representative"}},
+						},
+					},
+				},
+			},
+			Want: &hclext.BodyContent{
+				Blocks: hclext.Blocks{
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "count"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{
+								"instance_type":         &hclext.Attribute{Name: "instance_type"},
+								"tflint_representative": &hclext.Attribute{Name: "tflint_representative"},
+							},
+							Blocks: hclext.Blocks{},
+						},
+					},
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "for_each"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{
+								"instance_type":         &hclext.Attribute{Name: "instance_type"},
+								"tflint_representative": &hclext.Attribute{Name: "tflint_representative"},
+							},
+							Blocks: hclext.Blocks{},
+						},
+					},
+					{
+						Type:   "resource",
+						Labels: []string{"aws_instance", "known"},
+						Body: &hclext.BodyContent{
+							Attributes: hclext.Attributes{"instance_type": &hclext.Attribute{Name: "instance_type"}},
+							Blocks:     hclext.Blocks{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, diags := server.GetModuleContent(test.Schema, sdk.GetModuleContentOption{})
+			if diags.HasErrors() {
+				t.Fatalf("failed to call GetModuleContent: %s", diags)
+			}
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(hclext.Block{}, "TypeRange", "LabelRanges", "DefRange"),
+				cmpopts.IgnoreFields(hclext.Attribute{}, "Expr", "Range", "NameRange"),
+			}
+			if diff := cmp.Diff(got, test.Want, opts); diff != "" {
+				t.Error(diff)
+			}
+
+			for _, block := range got.Blocks {
+				attr, exists := block.Body.Attributes["tflint_representative"]
+				if !exists {
+					continue
+				}
+				val, diags := attr.Expr.Value(nil)
+				if diags.HasErrors() {
+					t.Fatal(diags)
+				}
+				if !val.True() {
+					t.Errorf("%s: marker is not true: %s", block.Labels[1], val.GoString())
+				}
+				instanceType, diags := block.Body.Attributes["instance_type"].Expr.Value(nil)
+				if diags.HasErrors() {
+					t.Fatal(diags)
+				}
+				if block.Labels[1] == "for_each" && instanceType.IsKnown() {
+					t.Errorf("each.key is not unknown: %s", instanceType.GoString())
+				}
+			}
+		})
+	}
+}
+
 func TestGetFile(t *testing.T) {
 	tests := []struct {
 		Name    string
@@ -5,7 +5,11 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
+	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
@@ -13,6 +17,13 @@ import (
 // Annotation represents comments with special meaning in TofuLint
 type Annotation interface {
 	IsAffected(*Issue) bool
+	// Rules returns the rule names to be ignored. It may include "all".
+	Rules() []string
+	// Range returns the range of the annotation comment.
+	Range() hcl.Range
+	// Justification returns the reason and the expiry date written after the rule names.
+	// The expiry date is zero if not written.
+	Justification() (string, time.Time)
 	String() string
 }
 
@@ -20,6 +31,7 @@ type Annotation interface {
 type Annotations []Annotation
 
 // NewAnnotations find annotations from the passed tokens and return that list.
+// The file is also used to resolve the block that tflint-ignore-block annotations apply to.
 func NewAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics) {
 	ret := Annotations{}
 
@@ -28,6 +40,9 @@ func NewAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 		return ret, diags
 	}
 
+	var blocks []hcl.Range
+	starts := []*RangeAnnotation{}
+
 	for _, token := range tokens {
 		if token.Type != hclsyntax.TokenComment {
 			continue
@@ -36,8 +51,15 @@ func NewAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 		// tflint-ignore annotation
 		match := lineAnnotationPattern.FindStringSubmatch(string(token.Bytes))
 		if len(match) == 2 {
+			content, reason, expires, diag := parseAnnotationContent(match[1], token)
+			if diag != nil {
+				diags = append(diags, diag)
+				continue
+			}
 			ret = append(ret, &LineAnnotation{
-				Content: strings.TrimSpace(match[1]),
+				Content: content,
+				Reason:  reason,
+				Expires: expires,
 				Token:   token,
 			})
 			continue
@@ -55,22 +77,266 @@ func NewAnnotations(path string, file *hcl.File) (Annotations, hcl.Diagnostics)
 				})
 				continue
 			}
+			content, reason, expires, diag := parseAnnotationContent(match[1], token)
+			if diag != nil {
+				diags = append(diags, diag)
+				continue
+			}
 			ret = append(ret, &FileAnnotation{
-				Content: strings.TrimSpace(match[1]),
+				Content: content,
+				Reason:  reason,
+				Expires: expires,
 				Token:   token,
 			})
 			continue
 		}
+
+		// tflint-ignore-block annotation
+		match = blockAnnotationPattern.FindStringSubmatch(string(token.Bytes))
+		if len(match) == 2 {
+			if blocks == nil {
+				blocks = listBlockRanges(file.Body)
+			}
+			block, found := nextBlockRange(blocks, token.Range.End)
+			if !found {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "tflint-ignore-block annotation must be followed by a block",
+					Detail:   fmt.Sprintf("No block found after the tflint-ignore-block annotation at line %d", token.Range.Start.Line),
+					Subject:  token.Range.Ptr(),
+				})
+				continue
+			}
+			content, reason, expires, diag := parseAnnotationContent(match[1], token)
+			if diag != nil {
+				diags = append(diags, diag)
+				continue
+			}
+			ret = append(ret, &BlockAnnotation{
+				Content: content,
+				Reason:  reason,
+				Expires: expires,
+				Token:   token,
+				Block:   block,
+			})
+			continue
+		}
+
+		// tflint-ignore-module annotation
+		match = moduleAnnotationPattern.FindStringSubmatch(string(token.Bytes))
+		if len(match) == 2 {
+			moduleCall, found := nextModuleCallRange(file.Body, token.Range.End)
+			if !found {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "tflint-ignore-module annotation must be followed by a module block",
+					Detail:   fmt.Sprintf("No module block found after the tflint-ignore-module annotation at line %d", token.Range.Start.Line),
+					Subject:  token.Range.Ptr(),
+				})
+				continue
+			}
+			content, reason, expires, diag := parseAnnotationContent(match[1], token)
+			if diag != nil {
+				diags = append(diags, diag)
+				continue
+			}
+			ret = append(ret, &ModuleAnnotation{
+				Content:    content,
+				Reason:     reason,
+				Expires:    expires,
+				Token:      token,
+				ModuleCall: moduleCall,
+			})
+			continue
+		}
+
+		// tflint-ignore-start annotation
+		match = rangeStartAnnotationPattern.FindStringSubmatch(string(token.Bytes))
+		if len(match) == 2 {
+			content, reason, expires, diag := parseAnnotationContent(match[1], token)
+			if diag != nil {
+				diags = append(diags, diag)
+				// Keep the start annotation to match the following tflint-ignore-end annotation
+			}
+			starts = append(starts, &RangeAnnotation{
+				Content: content,
+				Reason:  reason,
+				Expires: expires,
+				Token:   token,
+			})
+			continue
+		}
+
+		// tflint-ignore-end annotation closes the last tflint-ignore-start annotation
+		if rangeEndAnnotationPattern.Match(token.Bytes) {
+			if len(starts) == 0 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "tflint-ignore-end annotation must be preceded by tflint-ignore-start annotation",
+					Detail:   fmt.Sprintf("No tflint-ignore-start annotation found before line %d", token.Range.Start.Line),
+					Subject:  token.Range.Ptr(),
+				})
+				continue
+			}
+			start := starts[len(starts)-1]
+			starts = starts[:len(starts)-1]
+			start.EndToken = token
+			ret = append(ret, start)
+			continue
+		}
+	}
+
+	for _, start := range starts {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "tflint-ignore-start annotation must be closed by tflint-ignore-end annotation",
+			Detail:   fmt.Sprintf("No tflint-ignore-end annotation found after line %d", start.Token.Range.Start.Line),
+			Subject:  start.Token.Range.Ptr(),
+		})
 	}
 
 	return ret, diags
 }
 
-var lineAnnotationPattern = regexp.MustCompile(`tflint-ignore: ([^\n*/#]+)`)
+// listBlockRanges returns the ranges of all blocks in the body, including nested blocks.
+// The ranges are sorted by the start position.
+func listBlockRanges(body hcl.Body) []hcl.Range {
+	ret := []hcl.Range{}
+
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return ret
+	}
+	for _, block := range syntaxBody.Blocks {
+		ret = append(ret, block.Range())
+		ret = append(ret, listBlockRanges(block.Body)...)
+	}
+	return ret
+}
+
+// nextBlockRange returns the first block that starts after the passed position.
+func nextBlockRange(blocks []hcl.Range, pos hcl.Pos) (hcl.Range, bool) {
+	for _, block := range blocks {
+		if block.Start.Byte >= pos.Byte {
+			return block, true
+		}
+	}
+	return hcl.Range{}, false
+}
+
+// nextModuleCallRange returns the module block that follows the passed position.
+// It returns false if the next top-level block is not a module block.
+func nextModuleCallRange(body hcl.Body, pos hcl.Pos) (hcl.Range, bool) {
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return hcl.Range{}, false
+	}
+	for _, block := range syntaxBody.Blocks {
+		if block.Range().Start.Byte >= pos.Byte {
+			return block.Range(), block.Type == "module"
+		}
+	}
+	return hcl.Range{}, false
+}
+
+var annotationExpiryPattern = regexp.MustCompile(`\(expires ([^)]*)\)\s*$`)
+
+// parseAnnotationContent splits the annotation content like "rule_name -- reason (expires 2006-01-02)"
+// into the rule names, the reason, and the expiry date. Both the reason and the expiry date are optional.
+func parseAnnotationContent(raw string, token hclsyntax.Token) (string, string, time.Time, *hcl.Diagnostic) {
+	var expires time.Time
+	raw = trimAnnotationContent(raw)
+
+	if match := annotationExpiryPattern.FindStringSubmatchIndex(raw); match != nil {
+		date := raw[match[2]:match[3]]
+		var err error
+		expires, err = time.Parse(time.DateOnly, date)
+		if err != nil {
+			return "", "", time.Time{}, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid expiry date in annotation",
+				Detail:   fmt.Sprintf(`The expiry date "%s" must be in the format YYYY-MM-DD`, date),
+				Subject:  token.Range.Ptr(),
+			}
+		}
+		raw = strings.TrimSpace(raw[:match[0]])
+	}
+
+	content, reason, _ := strings.Cut(raw, "--")
+	return strings.TrimSpace(content), strings.TrimSpace(reason), expires, nil
+}
+
+// trimAnnotationContent trims the text following the annotation content.
+// Rule names end at the comment terminator or another comment, like "rule_name # comment",
+// but the reason can contain any characters, like URLs, up to the end of the line or comment.
+func trimAnnotationContent(raw string) string {
+	raw = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), "*/"))
+
+	end := strings.IndexAny(raw, "*/#")
+	if sep := strings.Index(raw, "--"); end >= 0 && (sep < 0 || end < sep) {
+		raw = raw[:end]
+	}
+	return strings.TrimSpace(raw)
+}
+
+// annotationExpired checks if the expiry date has passed. Annotations are valid until the end of the expiry date.
+func annotationExpired(expires time.Time, now time.Time) bool {
+	if expires.IsZero() {
+		return false
+	}
+	return !now.Before(expires.AddDate(0, 0, 1))
+}
+
+// formatAnnotationContent returns the annotation content with the reason and the expiry date.
+func formatAnnotationContent(content string, reason string, expires time.Time) string {
+	if reason != "" {
+		content += " -- " + reason
+	}
+	if !expires.IsZero() {
+		content += fmt.Sprintf(" (expires %s)", expires.Format(time.DateOnly))
+	}
+	return content
+}
+
+// annotationRules returns the rule names in the annotation content.
+func annotationRules(content string) []string {
+	rules := strings.Split(content, ",")
+	for i, rule := range rules {
+		rules[i] = strings.TrimSpace(rule)
+	}
+	return rules
+}
+
+// matchesRule checks if the annotation content contains the rule of the passed issue or "all".
+func matchesRule(content string, issue *Issue) bool {
+	rules := annotationRules(content)
+	return slices.Contains(rules, issue.Rule.Name()) || slices.Contains(rules, "all")
+}
+
+// commentRange returns the range of the comment token, excluding the trailing newline of single-line comments.
+func commentRange(token hclsyntax.Token) hcl.Range {
+	rng := token.Range
+	text := strings.TrimRight(string(token.Bytes), "\r\n")
+	if len(text) == len(token.Bytes) {
+		return rng
+	}
+	rng.End = hcl.Pos{
+		Line:   rng.Start.Line,
+		Column: rng.Start.Column + utf8.RuneCountInString(text),
+		Byte:   rng.Start.Byte + len(text),
+	}
+	return rng
+}
+
+var lineAnnotationPattern = regexp.MustCompile(`tflint-ignore: ([^\n]+)`)
 
 // LineAnnotation is an annotation for ignoring issues in a line
 type LineAnnotation struct {
 	Content string
+	// Reason is the justification written after "--"
+	Reason string
+	// Expires is the date until which the annotation is valid
+	Expires time.Time
 	Token   hclsyntax.Token
 }
 
@@ -79,13 +345,11 @@ func (a *LineAnnotation) IsAffected(issue *Issue) bool {
 	if a.Token.Range.Filename != issue.Range.Filename {
 		return false
 	}
-
-	rules := strings.Split(a.Content, ",")
-	for i, rule := range rules {
-		rules[i] = strings.TrimSpace(rule)
+	if annotationExpired(a.Expires, time.Now()) {
+		return false
 	}
 
-	if slices.Contains(rules, issue.Rule.Name()) || slices.Contains(rules, "all") {
+	if matchesRule(a.Content, issue) {
 		if a.Token.Range.Start.Line == issue.Range.Start.Line {
 			return true
 		}
@@ -96,16 +360,35 @@ func (a *LineAnnotation) IsAffected(issue *Issue) bool {
 	return false
 }
 
+// Rules returns the rule names to be ignored
+func (a *LineAnnotation) Rules() []string {
+	return annotationRules(a.Content)
+}
+
+// Range returns the range of the annotation comment
+func (a *LineAnnotation) Range() hcl.Range {
+	return commentRange(a.Token)
+}
+
+// Justification returns the reason and the expiry date
+func (a *LineAnnotation) Justification() (string, time.Time) {
+	return a.Reason, a.Expires
+}
+
 // String returns the string representation of the annotation
 func (a *LineAnnotation) String() string {
-	return fmt.Sprintf("tflint-ignore: %s (%s)", a.Content, a.Token.Range.String())
+	return fmt.Sprintf("tflint-ignore: %s (%s)", formatAnnotationContent(a.Content, a.Reason, a.Expires), a.Token.Range.String())
 }
 
-var fileAnnotationPattern = regexp.MustCompile(`tflint-ignore-file: ([^\n*/#]+)`)
+var fileAnnotationPattern = regexp.MustCompile(`tflint-ignore-file: ([^\n]+)`)
 
 // FileAnnotation is an annotation for ignoring issues in a file
 type FileAnnotation struct {
 	Content string
+	// Reason is the justification written after "--"
+	Reason string
+	// Expires is the date until which the annotation is valid
+	Expires time.Time
 	Token   hclsyntax.Token
 }
 
@@ -114,19 +397,236 @@ func (a *FileAnnotation) IsAffected(issue *Issue) bool {
 	if a.Token.Range.Filename != issue.Range.Filename {
 		return false
 	}
+	if annotationExpired(a.Expires, time.Now()) {
+		return false
+	}
 
-	rules := strings.Split(a.Content, ",")
-	for i, rule := range rules {
-		rules[i] = strings.TrimSpace(rule)
+	return matchesRule(a.Content, issue)
+}
+
+// Rules returns the rule names to be ignored
+func (a *FileAnnotation) Rules() []string {
+	return annotationRules(a.Content)
+}
+
+// Range returns the range of the annotation comment
+func (a *FileAnnotation) Range() hcl.Range {
+	return commentRange(a.Token)
+}
+
+// Justification returns the reason and the expiry date
+func (a *FileAnnotation) Justification() (string, time.Time) {
+	return a.Reason, a.Expires
+}
+
+// String returns the string representation of the annotation
+func (a *FileAnnotation) String() string {
+	return fmt.Sprintf("tflint-ignore-file: %s (%s)", formatAnnotationContent(a.Content, a.Reason, a.Expires), a.Token.Range.String())
+}
+
+var blockAnnotationPattern = regexp.MustCompile(`tflint-ignore-block: ([^\n]+)`)
+
+// BlockAnnotation is an annotation for ignoring issues in the next block
+type BlockAnnotation struct {
+	Content string
+	// Reason is the justification written after "--"
+	Reason string
+	// Expires is the date until which the annotation is valid
+	Expires time.Time
+	Token   hclsyntax.Token
+	// Block is the range of the block following the annotation
+	Block hcl.Range
+}
+
+// IsAffected checks if the passed issue is affected with the annotation
+func (a *BlockAnnotation) IsAffected(issue *Issue) bool {
+	if a.Token.Range.Filename != issue.Range.Filename {
+		return false
+	}
+	if annotationExpired(a.Expires, time.Now()) {
+		return false
 	}
 
-	if slices.Contains(rules, issue.Rule.Name()) || slices.Contains(rules, "all") {
-		return true
+	if matchesRule(a.Content, issue) {
+		return a.Block.Start.Line <= issue.Range.Start.Line && issue.Range.Start.Line <= a.Block.End.Line
 	}
 	return false
 }
 
+// Rules returns the rule names to be ignored
+func (a *BlockAnnotation) Rules() []string {
+	return annotationRules(a.Content)
+}
+
+// Range returns the range of the annotation comment
+func (a *BlockAnnotation) Range() hcl.Range {
+	return commentRange(a.Token)
+}
+
+// Justification returns the reason and the expiry date
+func (a *BlockAnnotation) Justification() (string, time.Time) {
+	return a.Reason, a.Expires
+}
+
 // String returns the string representation of the annotation
-func (a *FileAnnotation) String() string {
-	return fmt.Sprintf("tflint-ignore-file: %s (%s)", a.Content, a.Token.Range.String())
+func (a *BlockAnnotation) String() string {
+	return fmt.Sprintf("tflint-ignore-block: %s (%s)", formatAnnotationContent(a.Content, a.Reason, a.Expires), a.Token.Range.String())
+}
+
+var moduleAnnotationPattern = regexp.MustCompile(`tflint-ignore-module: ([^\n]+)`)
+
+// ModuleAnnotation is an annotation for ignoring issues in the module called by the next module block.
+// Issues found in the module are reported with callers, and the first caller is
+// the module argument in the module block, so it is used to match the annotation.
+type ModuleAnnotation struct {
+	Content string
+	// Reason is the justification written after "--"
+	Reason string
+	// Expires is the date until which the annotation is valid
+	Expires time.Time
+	Token   hclsyntax.Token
+	// ModuleCall is the range of the module block following the annotation
+	ModuleCall hcl.Range
+}
+
+// IsAffected checks if the passed issue is affected with the annotation
+func (a *ModuleAnnotation) IsAffected(issue *Issue) bool {
+	if len(issue.Callers) == 0 {
+		return false
+	}
+	caller := issue.Callers[0]
+	if a.Token.Range.Filename != caller.Filename {
+		return false
+	}
+	if annotationExpired(a.Expires, time.Now()) {
+		return false
+	}
+
+	if matchesRule(a.Content, issue) {
+		return a.ModuleCall.Start.Line <= caller.Start.Line && caller.Start.Line <= a.ModuleCall.End.Line
+	}
+	return false
+}
+
+// Rules returns the rule names to be ignored
+func (a *ModuleAnnotation) Rules() []string {
+	return annotationRules(a.Content)
+}
+
+// Range returns the range of the annotation comment
+func (a *ModuleAnnotation) Range() hcl.Range {
+	return commentRange(a.Token)
+}
+
+// Justification returns the reason and the expiry date
+func (a *ModuleAnnotation) Justification() (string, time.Time) {
+	return a.Reason, a.Expires
+}
+
+// String returns the string representation of the annotation
+func (a *ModuleAnnotation) String() string {
+	return fmt.Sprintf("tflint-ignore-module: %s (%s)", formatAnnotationContent(a.Content, a.Reason, a.Expires), a.Token.Range.String())
+}
+
+var rangeStartAnnotationPattern = regexp.MustCompile(`tflint-ignore-start: ([^\n]+)`)
+var rangeEndAnnotationPattern = regexp.MustCompile(`tflint-ignore-end\b`)
+
+// RangeAnnotation is an annotation for ignoring issues between
+// tflint-ignore-start and tflint-ignore-end annotations
+type RangeAnnotation struct {
+	Content string
+	// Reason is the justification written after "--"
+	Reason string
+	// Expires is the date until which the annotation is valid
+	Expires  time.Time
+	Token    hclsyntax.Token
+	EndToken hclsyntax.Token
+}
+
+// IsAffected checks if the passed issue is affected with the annotation
+func (a *RangeAnnotation) IsAffected(issue *Issue) bool {
+	if a.Token.Range.Filename != issue.Range.Filename {
+		return false
+	}
+	if annotationExpired(a.Expires, time.Now()) {
+		return false
+	}
+
+	if matchesRule(a.Content, issue) {
+		return a.Token.Range.Start.Line <= issue.Range.Start.Line && issue.Range.Start.Line <= a.EndToken.Range.Start.Line
+	}
+	return false
+}
+
+// Rules returns the rule names to be ignored
+func (a *RangeAnnotation) Rules() []string {
+	return annotationRules(a.Content)
+}
+
+// Range returns the range of the annotation comment
+func (a *RangeAnnotation) Range() hcl.Range {
+	return commentRange(a.Token)
+}
+
+// Justification returns the reason and the expiry date
+func (a *RangeAnnotation) Justification() (string, time.Time) {
+	return a.Reason, a.Expires
+}
+
+// String returns the string representation of the annotation
+func (a *RangeAnnotation) String() string {
+	return fmt.Sprintf("tflint-ignore-start: %s (%s:%d-%d)", formatAnnotationContent(a.Content, a.Reason, a.Expires), a.Token.Range.Filename, a.Token.Range.Start.Line, a.EndToken.Range.Start.Line)
+}
+
+// annotationUsage records annotations that ignored any issues.
+// It is shared between runners, so it is goroutine-safe.
+type annotationUsage struct {
+	mu   sync.Mutex
+	used map[Annotation]bool
+}
+
+func newAnnotationUsage() *annotationUsage {
+	return &annotationUsage{used: map[Annotation]bool{}}
+}
+
+func (u *annotationUsage) add(annotation Annotation) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.used[annotation] = true
+}
+
+func (u *annotationUsage) isUsed(annotation Annotation) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.used[annotation]
+}
+
+// unusedAnnotationRule is a built-in rule for reporting annotations that have no effect.
+type unusedAnnotationRule struct{}
+
+func (r *unusedAnnotationRule) Name() string {
+	return "tflint_unused_annotation"
+}
+
+func (r *unusedAnnotationRule) Severity() Severity {
+	return sdk.WARNING
+}
+
+func (r *unusedAnnotationRule) Link() string {
+	return fmt.Sprintf("https://github.com/arsiba/tofulint/blob/v%s/docs/user-guide/annotations.md", Version)
+}
+
+// annotationPolicyRule is a built-in rule for reporting annotations that are expired or violate the annotation config.
+type annotationPolicyRule struct{}
+
+func (r *annotationPolicyRule) Name() string {
+	return "tflint_annotation_policy"
+}
+
+func (r *annotationPolicyRule) Severity() Severity {
+	return sdk.ERROR
+}
+
+func (r *annotationPolicyRule) Link() string {
+	return fmt.Sprintf("https://github.com/arsiba/tofulint/blob/v%s/docs/user-guide/annotations.md", Version)
 }
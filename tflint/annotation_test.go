@@ -1,7 +1,9 @@
 package tflint
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -147,6 +149,111 @@ resource "aws_instance" "foo" {
 				},
 			},
 		},
+		{
+			name: "with reason and expiry",
+			src: `
+resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_invalid_type -- Required by the legacy AMI (expires 2027-01-31)
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&LineAnnotation{
+					Content: "aws_instance_invalid_type",
+					Reason:  "Required by the legacy AMI",
+					Expires: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore: aws_instance_invalid_type -- Required by the legacy AMI (expires 2027-01-31)\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3},
+							End:      hcl.Pos{Line: 4, Column: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with URL in reason",
+			src: `
+resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_invalid_type -- see https://jira/X-1 #42 (expires 2027-01-31)
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&LineAnnotation{
+					Content: "aws_instance_invalid_type",
+					Reason:  "see https://jira/X-1 #42",
+					Expires: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore: aws_instance_invalid_type -- see https://jira/X-1 #42 (expires 2027-01-31)\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3},
+							End:      hcl.Pos{Line: 4, Column: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with URL in reason in block comment",
+			src: `
+resource "aws_instance" "foo" {
+  /* tflint-ignore: aws_instance_invalid_type -- see https://jira/X-1 (expires 2027-01-31) */
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&LineAnnotation{
+					Content: "aws_instance_invalid_type",
+					Reason:  "see https://jira/X-1",
+					Expires: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("/* tflint-ignore: aws_instance_invalid_type -- see https://jira/X-1 (expires 2027-01-31) */"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3},
+							End:      hcl.Pos{Line: 3, Column: 94},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with expiry only",
+			src: `
+resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_invalid_type, aws_instance_previous_type (expires 2027-01-31)
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&LineAnnotation{
+					Content: "aws_instance_invalid_type, aws_instance_previous_type",
+					Expires: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore: aws_instance_invalid_type, aws_instance_previous_type (expires 2027-01-31)\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3},
+							End:      hcl.Pos{Line: 4, Column: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with invalid expiry",
+			src: `
+resource "aws_instance" "foo" {
+  # tflint-ignore: aws_instance_invalid_type -- Required by the legacy AMI (expires 2027-13-01)
+  instance_type = "t2.micro"
+}`,
+			want:  Annotations{},
+			diags: `resource.tf:3,3-4,1: Invalid expiry date in annotation; The expiry date "2027-13-01" must be in the format YYYY-MM-DD`,
+		},
 		{
 			name: "tflint-ignore-file annotation",
 			src: `# tflint-ignore-file: aws_instance_invalid_type
@@ -186,6 +293,167 @@ resource "aws_instance" "foo" {
 			want:  Annotations{},
 			diags: "resource.tf:1,33-2,1: tflint-ignore-file annotation must be written at the top of file; tflint-ignore-file annotation is written at line 1, column 33",
 		},
+		{
+			name: "tflint-ignore-block annotation",
+			src: `
+# tflint-ignore-block: aws_instance_invalid_type
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}`,
+			want: Annotations{
+				&BlockAnnotation{
+					Content: "aws_instance_invalid_type",
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore-block: aws_instance_invalid_type\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 3, Column: 1},
+						},
+					},
+					Block: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 2},
+					},
+				},
+			},
+		},
+		{
+			name: "tflint-ignore-block annotation for a nested block",
+			src: `
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+
+  // tflint-ignore-block: aws_instance_invalid_tag
+  dynamic "tag" {
+    for_each = var.tags
+    content {
+      key = tag.key
+    }
+  }
+}`,
+			want: Annotations{
+				&BlockAnnotation{
+					Content: "aws_instance_invalid_tag",
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("// tflint-ignore-block: aws_instance_invalid_tag\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 5, Column: 3},
+							End:      hcl.Pos{Line: 6, Column: 1},
+						},
+					},
+					Block: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "tflint-ignore-block annotation without blocks",
+			src: `
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+# tflint-ignore-block: aws_instance_invalid_type`,
+			want:  Annotations{},
+			diags: "resource.tf:5,1-49: tflint-ignore-block annotation must be followed by a block; No block found after the tflint-ignore-block annotation at line 5",
+		},
+		{
+			name: "tflint-ignore-module annotation",
+			src: `
+# tflint-ignore-module: aws_instance_invalid_type -- Upstream module
+module "instances" {
+  source = "./instances"
+}`,
+			want: Annotations{
+				&ModuleAnnotation{
+					Content: "aws_instance_invalid_type",
+					Reason:  "Upstream module",
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore-module: aws_instance_invalid_type -- Upstream module\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 3, Column: 1},
+						},
+					},
+					ModuleCall: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 2},
+					},
+				},
+			},
+		},
+		{
+			name: "tflint-ignore-module annotation without module blocks",
+			src: `
+# tflint-ignore-module: aws_instance_invalid_type
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}`,
+			want:  Annotations{},
+			diags: "resource.tf:2,1-3,1: tflint-ignore-module annotation must be followed by a module block; No module block found after the tflint-ignore-module annotation at line 2",
+		},
+		{
+			name: "tflint-ignore-start and tflint-ignore-end annotations",
+			src: `
+# tflint-ignore-start: aws_instance_invalid_type
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+# tflint-ignore-end`,
+			want: Annotations{
+				&RangeAnnotation{
+					Content: "aws_instance_invalid_type",
+					Token: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore-start: aws_instance_invalid_type\n"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 3, Column: 1},
+						},
+					},
+					EndToken: hclsyntax.Token{
+						Type:  hclsyntax.TokenComment,
+						Bytes: []byte("# tflint-ignore-end"),
+						Range: hcl.Range{
+							Filename: "resource.tf",
+							Start:    hcl.Pos{Line: 6, Column: 1},
+							End:      hcl.Pos{Line: 6, Column: 20},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "tflint-ignore-start annotation without tflint-ignore-end",
+			src: `
+# tflint-ignore-start: aws_instance_invalid_type
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}`,
+			want:  Annotations{},
+			diags: "resource.tf:2,1-3,1: tflint-ignore-start annotation must be closed by tflint-ignore-end annotation; No tflint-ignore-end annotation found after line 2",
+		},
+		{
+			name: "tflint-ignore-end annotation without tflint-ignore-start",
+			src: `
+resource "aws_instance" "foo" {
+  instance_type = "t2.micro"
+}
+# tflint-ignore-end`,
+			want:  Annotations{},
+			diags: "resource.tf:5,1-20: tflint-ignore-end annotation must be preceded by tflint-ignore-start annotation; No tflint-ignore-start annotation found before line 5",
+		},
 	}
 
 	for _, test := range tests {
@@ -322,6 +590,36 @@ func TestLineAnnotation_IsAffected(t *testing.T) {
 			},
 			Expected: false,
 		},
+		{
+			Name: "affected (not expired)",
+			Annotation: &LineAnnotation{
+				Content: "test_rule",
+				Expires: time.Date(2999, 12, 31, 0, 0, 0, 0, time.UTC),
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "not affected (expired)",
+			Annotation: &LineAnnotation{
+				Content: "test_rule",
+				Expires: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2},
+					},
+				},
+			},
+			Expected: false,
+		},
 		{
 			Name: "affected (all)",
 			Annotation: &LineAnnotation{
@@ -452,3 +750,242 @@ func TestFileAnnotation_IsAffected(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockAnnotation_IsAffected(t *testing.T) {
+	annotation := &BlockAnnotation{
+		Content: "test_rule",
+		Token: hclsyntax.Token{
+			Type: hclsyntax.TokenComment,
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+		},
+		Block: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2},
+			End:      hcl.Pos{Line: 5},
+		},
+	}
+
+	tests := []struct {
+		Name     string
+		Issue    *Issue
+		Expected bool
+	}{
+		{
+			Name:     "affected (first line)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2}}},
+			Expected: true,
+		},
+		{
+			Name:     "affected (inside block)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 4}}},
+			Expected: true,
+		},
+		{
+			Name:     "affected (last line)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 5}}},
+			Expected: true,
+		},
+		{
+			Name:     "not affected (after block)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 6}}},
+			Expected: false,
+		},
+		{
+			Name:     "not affected (another filename)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test2.tf", Start: hcl.Pos{Line: 3}}},
+			Expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := annotation.IsAffected(test.Issue)
+			if got != test.Expected {
+				t.Fatalf("want=%t, got=%t", test.Expected, got)
+			}
+		})
+	}
+}
+
+func TestModuleAnnotation_IsAffected(t *testing.T) {
+	annotation := &ModuleAnnotation{
+		Content: "test_rule",
+		Token: hclsyntax.Token{
+			Type: hclsyntax.TokenComment,
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+		},
+		ModuleCall: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2},
+			End:      hcl.Pos{Line: 5},
+		},
+	}
+	callers := func(filename string, line int) []hcl.Range {
+		return []hcl.Range{
+			{Filename: filename, Start: hcl.Pos{Line: line}},
+			{Filename: filepath.Join("module", "main.tf"), Start: hcl.Pos{Line: 1}},
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Issue    *Issue
+		Expected bool
+	}{
+		{
+			Name:     "affected",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 3}}, Callers: callers("test.tf", 3)},
+			Expected: true,
+		},
+		{
+			Name:     "not affected (no callers)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 3}}},
+			Expected: false,
+		},
+		{
+			Name:     "not affected (another module call)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 7}}, Callers: callers("test.tf", 7)},
+			Expected: false,
+		},
+		{
+			Name:     "not affected (another filename)",
+			Issue:    &Issue{Rule: &testRule{}, Range: hcl.Range{Filename: "test2.tf", Start: hcl.Pos{Line: 3}}, Callers: callers("test2.tf", 3)},
+			Expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := annotation.IsAffected(test.Issue)
+			if got != test.Expected {
+				t.Fatalf("want=%t, got=%t", test.Expected, got)
+			}
+		})
+	}
+}
+
+func TestRangeAnnotation_IsAffected(t *testing.T) {
+	issue := &Issue{
+		Rule:    &testRule{},
+		Message: "Test rule",
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 4},
+		},
+	}
+	newAnnotation := func(content string, start int, end int) *RangeAnnotation {
+		return &RangeAnnotation{
+			Content: content,
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: start}},
+			},
+			EndToken: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: end}},
+			},
+		}
+	}
+
+	tests := []struct {
+		Name       string
+		Annotation *RangeAnnotation
+		Expected   bool
+	}{
+		{
+			Name:       "affected",
+			Annotation: newAnnotation("test_rule", 2, 6),
+			Expected:   true,
+		},
+		{
+			Name:       "affected (multiple rules)",
+			Annotation: newAnnotation("other_rule, test_rule", 2, 6),
+			Expected:   true,
+		},
+		{
+			Name:       "affected (all)",
+			Annotation: newAnnotation("all", 4, 4),
+			Expected:   true,
+		},
+		{
+			Name:       "not affected (before range)",
+			Annotation: newAnnotation("test_rule", 5, 6),
+			Expected:   false,
+		},
+		{
+			Name:       "not affected (after range)",
+			Annotation: newAnnotation("test_rule", 1, 3),
+			Expected:   false,
+		},
+		{
+			Name:       "not affected (another rule)",
+			Annotation: newAnnotation("other_rule", 2, 6),
+			Expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := test.Annotation.IsAffected(issue)
+			if got != test.Expected {
+				t.Fatalf("want=%t, got=%t", test.Expected, got)
+			}
+		})
+	}
+}
+
+func TestAnnotation_String(t *testing.T) {
+	token := hclsyntax.Token{
+		Type: hclsyntax.TokenComment,
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2, Column: 1},
+			End:      hcl.Pos{Line: 3, Column: 1},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		annotation Annotation
+		want       string
+	}{
+		{
+			name:       "line annotation",
+			annotation: &LineAnnotation{Content: "test_rule", Token: token},
+			want:       "tflint-ignore: test_rule (test.tf:2,1-3,1)",
+		},
+		{
+			name: "line annotation with reason and expiry",
+			annotation: &LineAnnotation{
+				Content: "test_rule",
+				Reason:  "Accepted risk",
+				Expires: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+				Token:   token,
+			},
+			want: "tflint-ignore: test_rule -- Accepted risk (expires 2027-01-31) (test.tf:2,1-3,1)",
+		},
+		{
+			name: "file annotation with reason",
+			annotation: &FileAnnotation{
+				Content: "test_rule",
+				Reason:  "Generated file",
+				Token:   token,
+			},
+			want: "tflint-ignore-file: test_rule -- Generated file (test.tf:2,1-3,1)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.annotation.String(); got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package tflint
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+// baselineVersion is the version of the baseline file format.
+const baselineVersion = 1
+
+// Baseline is a set of issues recorded at a point in time.
+// Issues that match an entry in the baseline are considered pre-existing
+// and are not reported.
+type Baseline struct {
+	Version int              `json:"version"`
+	Entries []*BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a recorded issue in the baseline.
+// Entries are identified by a fingerprint that does not depend on line numbers,
+// so that the baseline stays valid even if unrelated code is added or removed.
+type BaselineEntry struct {
+	Rule        string `json:"rule"`
+	Filename    string `json:"filename"`
+	Fingerprint string `json:"fingerprint"`
+	Message     string `json:"message"`
+}
+
+// NewBaseline returns a baseline that records the passed issues.
+func NewBaseline(issues Issues) *Baseline {
+	baseline := &Baseline{Version: baselineVersion, Entries: []*BaselineEntry{}}
+
+	for _, issue := range issues {
+		baseline.Entries = append(baseline.Entries, newBaselineEntry(issue))
+	}
+	sort.SliceStable(baseline.Entries, func(i, j int) bool {
+		if baseline.Entries[i].Filename != baseline.Entries[j].Filename {
+			return baseline.Entries[i].Filename < baseline.Entries[j].Filename
+		}
+		if baseline.Entries[i].Rule != baseline.Entries[j].Rule {
+			return baseline.Entries[i].Rule < baseline.Entries[j].Rule
+		}
+		return baseline.Entries[i].Fingerprint < baseline.Entries[j].Fingerprint
+	})
+
+	return baseline
+}
+
+// LoadBaseline reads a baseline file from the given path.
+// If the file does not exist, an empty baseline is returned.
+func LoadBaseline(fs afero.Afero, path string) (*Baseline, error) {
+	src, err := fs.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewBaseline(Issues{}), nil
+		}
+		return nil, fmt.Errorf("failed to load baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(src, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d. Supported version is %d", path, baseline.Version, baselineVersion)
+	}
+	return &baseline, nil
+}
+
+// Write saves the baseline to the given path.
+func (b *Baseline) Write(fs afero.Afero, path string) error {
+	out, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(path, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Filter returns issues that are not recorded in the baseline,
+// and baseline entries that no longer match any issue.
+//
+// The same fingerprint can appear multiple times in a baseline (e.g. the same
+// snippet copied into several blocks), so each entry suppresses only one issue.
+func (b *Baseline) Filter(issues Issues) (Issues, []*BaselineEntry) {
+	remaining := map[string][]*BaselineEntry{}
+	for _, entry := range b.Entries {
+		remaining[entry.Fingerprint] = append(remaining[entry.Fingerprint], entry)
+	}
+
+	ret := Issues{}
+	matched := map[*BaselineEntry]bool{}
+	for _, issue := range issues {
+		fingerprint := issue.Fingerprint()
+		if entries := remaining[fingerprint]; len(entries) > 0 {
+			matched[entries[0]] = true
+			remaining[fingerprint] = entries[1:]
+			continue
+		}
+		ret = append(ret, issue)
+	}
+
+	stale := []*BaselineEntry{}
+	for _, entry := range b.Entries {
+		if !matched[entry] {
+			stale = append(stale, entry)
+		}
+	}
+
+	return ret, stale
+}
+
+func newBaselineEntry(issue *Issue) *BaselineEntry {
+	return &BaselineEntry{
+		Rule:        issue.Rule.Name(),
+		Filename:    filepath.ToSlash(issue.Range.Filename),
+		Fingerprint: issue.Fingerprint(),
+		Message:     issue.Message,
+	}
+}
+
+// Fingerprint returns a hash that identifies the issue regardless of its position.
+// It is derived from the rule name, the filename, and the normalized source code
+// of the lines covered by the issue range. If the source code is not available,
+// the message is used instead.
+func (i *Issue) Fingerprint() string {
+	snippet := normalizedSnippet(i.Source, i.Range)
+	if snippet == "" {
+		snippet = i.Message
+	}
+
+	h := sha256.New()
+	h.Write([]byte(i.Rule.Name()))
+	h.Write([]byte{0})
+	h.Write([]byte(filepath.ToSlash(i.Range.Filename)))
+	h.Write([]byte{0})
+	h.Write([]byte(snippet))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// normalizedSnippet returns the lines that overlap the range,
+// with leading/trailing whitespace removed and inner whitespace collapsed.
+func normalizedSnippet(src []byte, rng hcl.Range) string {
+	if src == nil {
+		return ""
+	}
+
+	lines := []string{}
+	sc := hcl.NewRangeScanner(src, rng.Filename, bufio.ScanLines)
+	for sc.Scan() {
+		lineRange := sc.Range()
+		if lineRange.Start.Line < rng.Start.Line || lineRange.Start.Line > rng.End.Line {
+			continue
+		}
+		lines = append(lines, strings.Join(strings.Fields(string(sc.Bytes())), " "))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,201 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func Test_IssueFingerprint(t *testing.T) {
+	original := &Issue{
+		Rule:    &testRule{},
+		Message: "test",
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2, Column: 3},
+			End:      hcl.Pos{Line: 2, Column: 9},
+		},
+		Source: []byte(`resource "null_resource" "foo" {
+  foo = "bar"
+}`),
+	}
+
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  bool
+	}{
+		{
+			name: "shifted lines",
+			issue: &Issue{
+				Rule:    &testRule{},
+				Message: "test",
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 4, Column: 3},
+					End:      hcl.Pos{Line: 4, Column: 9},
+				},
+				Source: []byte(`# comment
+
+resource "null_resource" "foo" {
+  foo = "bar"
+}`),
+			},
+			want: true,
+		},
+		{
+			name: "reformatted",
+			issue: &Issue{
+				Rule:    &testRule{},
+				Message: "test",
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 5},
+					End:      hcl.Pos{Line: 2, Column: 13},
+				},
+				Source: []byte(`resource "null_resource" "foo" {
+    foo   = "bar"
+}`),
+			},
+			want: true,
+		},
+		{
+			name: "changed source",
+			issue: &Issue{
+				Rule:    &testRule{},
+				Message: "test",
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3},
+					End:      hcl.Pos{Line: 2, Column: 9},
+				},
+				Source: []byte(`resource "null_resource" "foo" {
+  foo = "baz"
+}`),
+			},
+			want: false,
+		},
+		{
+			name: "different file",
+			issue: &Issue{
+				Rule:    &testRule{},
+				Message: "test",
+				Range: hcl.Range{
+					Filename: "main.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3},
+					End:      hcl.Pos{Line: 2, Column: 9},
+				},
+				Source: original.Source,
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := original.Fingerprint() == test.issue.Fingerprint()
+			if got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_BaselineFilter(t *testing.T) {
+	src := []byte(`foo = 1
+bar = 2
+baz = 3
+`)
+	issueAt := func(line int) *Issue {
+		return &Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: line, Column: 1},
+				End:      hcl.Pos{Line: line, Column: 4},
+			},
+			Source: src,
+		}
+	}
+
+	baseline := NewBaseline(Issues{issueAt(1), issueAt(2)})
+
+	got, stale := baseline.Filter(Issues{issueAt(1), issueAt(3)})
+	if diff := cmp.Diff(Issues{issueAt(3)}, got); diff != "" {
+		t.Errorf("issues: %s", diff)
+	}
+	want := []*BaselineEntry{newBaselineEntry(issueAt(2))}
+	if diff := cmp.Diff(want, stale); diff != "" {
+		t.Errorf("stale entries: %s", diff)
+	}
+}
+
+func Test_BaselineFilter_duplicated(t *testing.T) {
+	src := []byte(`foo = 1
+foo = 1
+`)
+	issueAt := func(line int) *Issue {
+		return &Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: line, Column: 1},
+				End:      hcl.Pos{Line: line, Column: 4},
+			},
+			Source: src,
+		}
+	}
+
+	baseline := NewBaseline(Issues{issueAt(1)})
+
+	got, stale := baseline.Filter(Issues{issueAt(1), issueAt(2)})
+	if diff := cmp.Diff(Issues{issueAt(2)}, got); diff != "" {
+		t.Errorf("issues: %s", diff)
+	}
+	if len(stale) != 0 {
+		t.Errorf("stale entries: got %d, want 0", len(stale))
+	}
+}
+
+func Test_LoadBaseline(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+
+	baseline := NewBaseline(Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range:   hcl.Range{Filename: "test.tf"},
+		},
+	})
+	if err := baseline.Write(fs, "baseline.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadBaseline(fs, "baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(baseline, got); diff != "" {
+		t.Error(diff)
+	}
+
+	got, err = LoadBaseline(fs, "not_found.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(NewBaseline(Issues{}), got); diff != "" {
+		t.Error(diff)
+	}
+
+	if err := fs.WriteFile("invalid.json", []byte(`{"version":2,"entries":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadBaseline(fs, "invalid.json")
+	if err == nil {
+		t.Fatal("expected an error, but got nil")
+	}
+}
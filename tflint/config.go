@@ -1,20 +1,26 @@
 package tflint
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/arsiba/tofulint-plugin-sdk/hclext"
 	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
 	"github.com/arsiba/tofulint/opentofu"
+	"github.com/bmatcuk/doublestar"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/afero"
+	"github.com/zclconf/go-cty/cty"
 )
 
 var defaultConfigFile = ".tflint.hcl"
@@ -35,6 +41,13 @@ var configSchema = &hcl.BodySchema{
 			Type:       "plugin",
 			LabelNames: []string{"name"},
 		},
+		{
+			Type: "annotation",
+		},
+		{
+			Type:       "override",
+			LabelNames: []string{"path"},
+		},
 	},
 }
 
@@ -49,6 +62,7 @@ var innerConfigSchema = &hcl.BodySchema{
 		{Name: "disabled_by_default"},
 		{Name: "plugin_dir"},
 		{Name: "format"},
+		{Name: "extends"},
 	},
 }
 
@@ -59,6 +73,21 @@ var validFormats = []string{
 	"junit",
 	"compact",
 	"sarif",
+	"markdown",
+	"github",
+	"gitlab",
+}
+
+// ParseFormat splits a format like "sarif:results.sarif" into the format name and the output path.
+// The path is empty if the format has no destination, which means stdout.
+func ParseFormat(s string) (string, string, error) {
+	format, path, _ := strings.Cut(s, ":")
+	for _, f := range validFormats {
+		if format == f {
+			return format, path, nil
+		}
+	}
+	return format, path, fmt.Errorf("%s is invalid format. Allowed formats are: %s", format, strings.Join(validFormats, ", "))
 }
 
 // Config describes the behavior of TofuLint
@@ -75,24 +104,61 @@ type Config struct {
 	PluginDir    string
 	PluginDirSet bool
 
-	Format    string
+	Formats   []string
 	FormatSet bool
 
 	Varfiles      []string
 	Variables     []string
 	Only          []string
 	IgnoreModules map[string]bool
+	Fix           bool
+	FixRules      []string
 	Rules         map[string]*RuleConfig
 	Plugins       map[string]*PluginConfig
+	Annotation    *AnnotationConfig
+	Overrides     []*OverrideConfig
 
 	sources map[string][]byte
+
+	// extends are paths of config files that this config extends.
+	// Relative paths are resolved from the directory of the config file.
+	extends      []string
+	extendsRange hcl.Range
 }
 
 // RuleConfig is a TofuLint's rule config
 type RuleConfig struct {
-	Name    string   `hcl:"name,label"`
-	Enabled bool     `hcl:"enabled"`
-	Body    hcl.Body `hcl:",remain"`
+	Name    string `hcl:"name,label"`
+	Enabled bool   `hcl:"enabled"`
+	// IgnoreModules are module sources where issues by the rule are ignored
+	IgnoreModules []string `hcl:"ignore_module,optional"`
+	// Include and Exclude are globs of file paths where issues by the rule are reported
+	Include []string `hcl:"include,optional"`
+	Exclude []string `hcl:"exclude,optional"`
+	// Severity overrides the severity of the rule. It is empty if not overridden.
+	Severity string   `hcl:"severity,optional"`
+	Body     hcl.Body `hcl:",remain"`
+
+	// baseDir is the absolute directory of the config file where the rule is declared.
+	// Include and Exclude are resolved from it. If empty, they are resolved from the current directory.
+	baseDir string
+}
+
+// OverrideConfig is a TofuLint's override config.
+// It overrides rule configs for files matching the path glob.
+type OverrideConfig struct {
+	Path  string                `hcl:"path,label"`
+	Rules []*OverrideRuleConfig `hcl:"rule,block"`
+
+	// baseDir is the absolute directory of the config file where the override is declared.
+	// Path is resolved from it. If empty, it is resolved from the current directory.
+	baseDir string
+}
+
+// OverrideRuleConfig is a rule config in an override block
+type OverrideRuleConfig struct {
+	Name    string `hcl:"name,label"`
+	Enabled bool   `hcl:"enabled"`
 }
 
 // PluginConfig is a TofuLint's plugin config
@@ -111,6 +177,13 @@ type PluginConfig struct {
 	SourceRepo  string
 }
 
+// AnnotationConfig is a TofuLint's annotation config.
+// It enforces justifications on annotations that ignore issues.
+type AnnotationConfig struct {
+	RequireReason bool `hcl:"require_reason,optional"`
+	MaxExpiryDays int  `hcl:"max_expiry_days,optional"`
+}
+
 // EmptyConfig returns default config
 // It is mainly used for testing
 func EmptyConfig() *Config {
@@ -130,15 +203,19 @@ func EmptyConfig() *Config {
 // The priority of the configuration files is as follows:
 //
 // 1. file passed by the --config option
-// 2. file set by the TFLINT_CONFIG_FILE environment variable^
-// 3. current directory (.tofulint.hcl)
-// 4. current directory (.tflint.hcl)
-// 5. home directory (~/.tofulint.hcl)
-// 6. current directory (./.tflint.hcl)
-// 7. home directory (~/.tflint.hcl)
+// 2. file set by the TFLINT_CONFIG_FILE environment variable
+// 3. current directory and its parents up to the repository root (.tofulint.hcl or .tflint.hcl)
+// 4. home directory (~/.tofulint.hcl)
+// 5. home directory (~/.tflint.hcl)
 //
 // For 1 and 2, if the file does not exist, an error will be returned immediately.
-// If 3 fails, fallback to 4, and If it fails, an empty configuration is returned.
+// For 3, all files found are merged, and the nearest one takes precedence.
+// A directory containing .git is regarded as the repository root. Outside a repository,
+// only the current directory is searched. In each directory, .tofulint.hcl is preferred over .tflint.hcl.
+// If no files are found, fallback to 4, and if it fails, fallback to 5.
+// If all fail, an empty configuration is returned.
+//
+// In any file, other files can be inherited by the "extends" attribute in the "config" block.
 //
 // It also automatically enables bundled plugin if the "opentofu"
 // plugin block is not explicitly declared.
@@ -150,7 +227,7 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to load file: %w", err)
 		}
-		cfg, err := loadConfig(f)
+		cfg, err := loadConfigFile(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
@@ -165,30 +242,39 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to load file: %w", err)
 		}
-		cfg, err := loadConfig(f)
+		cfg, err := loadConfigFile(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
 		return cfg.enableBundledPlugin(), nil
 	}
 
-	// Load the default ToFuLint config file
-	log.Printf("[INFO] Load config: %s", defaultTofuConfigFile)
-	if f, err := fs.Open(defaultTofuConfigFile); err == nil {
-		cfg, err := loadConfig(f)
-		if err != nil {
-			return nil, err
-		}
-		return cfg.enableBundledPlugin(), nil
+	// Load the config files from the repository root to the current directory
+	files, err := hierarchicalConfigFiles(fs)
+	if err != nil {
+		return nil, err
 	}
-	log.Printf("[INFO] file not found")
-
-	// Load the default TFLint config file
-	log.Printf("[INFO] Load config: %s", defaultConfigFile)
-	if f, err := fs.Open(defaultConfigFile); err == nil {
-		cfg, err := loadConfig(f)
-		if err != nil {
-			return nil, err
+	if len(files) > 0 {
+		var cfg *Config
+		for i, path := range files {
+			log.Printf("[INFO] Load config: %s", path)
+			f, err := fs.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load file: %w", err)
+			}
+			c, err := loadConfigFile(fs, f, []string{})
+			if err != nil {
+				// Errors without source locations are ambiguous if multiple files are loaded
+				if len(files) > 1 {
+					return nil, attributeError(path, err)
+				}
+				return nil, err
+			}
+			if i == 0 {
+				cfg = c
+			} else {
+				cfg.Merge(c)
+			}
 		}
 		return cfg.enableBundledPlugin(), nil
 	}
@@ -201,7 +287,7 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	}
 	log.Printf("[INFO] Load config: %s", fallbackTofu)
 	if f, err := fs.Open(fallbackTofu); err == nil {
-		cfg, err := loadConfig(f)
+		cfg, err := loadConfigFile(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
@@ -216,7 +302,7 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	}
 	log.Printf("[INFO] Load config: %s", fallback)
 	if f, err := fs.Open(fallback); err == nil {
-		cfg, err := loadConfig(f)
+		cfg, err := loadConfigFile(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
@@ -229,7 +315,147 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	return EmptyConfig().enableBundledPlugin(), nil
 }
 
-func loadConfig(file afero.File) (*Config, error) {
+// hierarchicalConfigFiles returns paths of config files in the current directory and its parents
+// up to the repository root. Paths are relative to the current directory, and ordered from the root.
+func hierarchicalConfigFiles(fs afero.Afero) ([]string, error) {
+	wd, err := filepath.Abs(opentofu.WorkingDir(fs.Fs))
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{}
+	dir := "."
+	for abs := wd; ; abs = filepath.Dir(abs) {
+		dirs = append(dirs, dir)
+		if exists, _ := fs.Exists(filepath.Join(dir, ".git")); exists {
+			break
+		}
+		if filepath.Dir(abs) == abs {
+			// Search only the current directory outside a repository
+			log.Print("[DEBUG] Repository root not found")
+			dirs = []string{"."}
+			break
+		}
+		dir = filepath.Join(dir, "..")
+	}
+
+	files := []string{}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		for _, name := range []string{defaultTofuConfigFile, defaultConfigFile} {
+			path := filepath.Join(dirs[i], name)
+			if exists, _ := fs.Exists(path); exists {
+				files = append(files, path)
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+// loadConfigFile loads the config file and merges it over the files it extends.
+// The stack is the chain of files extending this file, used for detecting cycles.
+func loadConfigFile(fs afero.Afero, file afero.File, stack []string) (*Config, error) {
+	// Relative paths in file functions are resolved from the directory of the config file
+	baseDir := filepath.Dir(file.Name())
+	if !filepath.IsAbs(baseDir) {
+		baseDir = filepath.Join(opentofu.WorkingDir(fs.Fs), baseDir)
+	}
+	cfg, err := loadConfig(file, configEvalContext(baseDir, opentofu.WorkspaceFromFs(fs)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Globs in include, exclude and override blocks are also resolved from the directory of the config file
+	absBaseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range cfg.Rules {
+		rule.baseDir = absBaseDir
+	}
+	for _, override := range cfg.Overrides {
+		override.baseDir = absBaseDir
+	}
+
+	if len(cfg.extends) == 0 {
+		return cfg, nil
+	}
+
+	path, err := filepath.Abs(file.Name())
+	if err != nil {
+		return nil, err
+	}
+	stack = append(stack[:len(stack):len(stack)], path)
+
+	var base *Config
+	for _, extend := range cfg.extends {
+		extendPath := extend
+		if !filepath.IsAbs(extendPath) {
+			extendPath = filepath.Join(filepath.Dir(file.Name()), extendPath)
+		}
+		abs, err := filepath.Abs(extendPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range stack {
+			if p == abs {
+				return nil, &attributedError{
+					location: cfg.extendsRange.String(),
+					err:      fmt.Errorf("cycle detected in extends: %s", strings.Join(append(stack, abs), " -> ")),
+				}
+			}
+		}
+
+		log.Printf("[INFO] Load extended config: %s", extendPath)
+		f, err := fs.Open(extendPath)
+		if err != nil {
+			return nil, &attributedError{location: cfg.extendsRange.String(), err: fmt.Errorf("failed to load file: %w", err)}
+		}
+		c, err := loadConfigFile(fs, f, stack)
+		if err != nil {
+			return nil, attributeError(extendPath, err)
+		}
+		if base == nil {
+			base = c
+		} else {
+			base.Merge(c)
+		}
+	}
+	base.Merge(cfg)
+
+	return base, nil
+}
+
+// attributeError attributes the error to the config file, unless it is diagnostics
+// or another error that already has the source location.
+func attributeError(path string, err error) error {
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) {
+		return err
+	}
+	var attributed *attributedError
+	if errors.As(err, &attributed) {
+		return err
+	}
+	return &attributedError{location: path, err: err}
+}
+
+// attributedError is an error with the location in config files where it occurred.
+// The location is a file path or a range.
+type attributedError struct {
+	location string
+	err      error
+}
+
+func (e *attributedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.location, e.err)
+}
+
+func (e *attributedError) Unwrap() error {
+	return e.err
+}
+
+func loadConfig(file afero.File, ctx *hcl.EvalContext) (*Config, error) {
 	src, err := afero.ReadAll(file)
 	if err != nil {
 		return nil, err
@@ -261,7 +487,7 @@ func loadConfig(file afero.File) (*Config, error) {
 				case "call_module_type":
 					var callModuleType string
 					config.CallModuleTypeSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &callModuleType); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &callModuleType); err != nil {
 						return config, err
 					}
 					config.CallModuleType, err = opentofu.AsCallModuleType(callModuleType)
@@ -278,7 +504,7 @@ func loadConfig(file afero.File) (*Config, error) {
 					}
 					var module bool
 					config.CallModuleTypeSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &module); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &module); err != nil {
 						return config, err
 					}
 					if module {
@@ -289,51 +515,68 @@ func loadConfig(file afero.File) (*Config, error) {
 
 				case "force":
 					config.ForceSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Force); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Force); err != nil {
 						return config, err
 					}
 
 				case "ignore_module":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.IgnoreModules); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.IgnoreModules); err != nil {
 						return config, err
 					}
 
 				case "varfile":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Varfiles); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Varfiles); err != nil {
 						return config, err
 					}
 
 				case "variables":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Variables); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Variables); err != nil {
 						return config, err
 					}
 
 				case "disabled_by_default":
 					config.DisabledByDefaultSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.DisabledByDefault); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.DisabledByDefault); err != nil {
 						return config, err
 					}
 
 				case "plugin_dir":
 					config.PluginDirSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.PluginDir); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.PluginDir); err != nil {
+						return config, err
+					}
+
+				case "extends":
+					config.extendsRange = attr.Range
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.extends); err != nil {
 						return config, err
 					}
 
 				case "format":
 					config.FormatSet = true
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Format); err != nil {
-						return config, err
+					// Both a string and a list of strings are accepted
+					val, diags := attr.Expr.Value(ctx)
+					if diags.HasErrors() {
+						return config, diags
 					}
-					formatValid := false
-					for _, f := range validFormats {
-						if config.Format == "" || config.Format == f {
-							formatValid = true
-							break
+					if val.Type() == cty.String {
+						var format string
+						if err := gohcl.DecodeExpression(attr.Expr, ctx, &format); err != nil {
+							return config, err
+						}
+						config.Formats = []string{format}
+					} else {
+						if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Formats); err != nil {
+							return config, err
 						}
 					}
-					if !formatValid {
-						return config, fmt.Errorf("%s is invalid format. Allowed formats are: %s", config.Format, strings.Join(validFormats, ", "))
+					for _, format := range config.Formats {
+						if format == "" {
+							continue
+						}
+						if _, _, err := ParseFormat(format); err != nil {
+							return config, err
+						}
 					}
 
 				default:
@@ -343,14 +586,28 @@ func loadConfig(file afero.File) (*Config, error) {
 
 		case "rule":
 			ruleConfig := &RuleConfig{Name: block.Labels[0]}
-			if err := gohcl.DecodeBody(block.Body, nil, ruleConfig); err != nil {
+			if err := gohcl.DecodeBody(block.Body, ctx, ruleConfig); err != nil {
 				return config, err
 			}
+			if ruleConfig.Severity != "" {
+				// Issues by built-in rules are emitted by TofuLint itself, so they always have the default severity
+				if slices.Contains(BuiltinRuleNames(), ruleConfig.Name) {
+					return config, fmt.Errorf(`rule "%s": severity cannot be overridden for built-in rules`, ruleConfig.Name)
+				}
+				if _, err := NewSeverity(ruleConfig.Severity); err != nil {
+					return config, fmt.Errorf(`rule "%s": %w`, ruleConfig.Name, err)
+				}
+			}
+			for _, pattern := range append(ruleConfig.Include, ruleConfig.Exclude...) {
+				if err := validateGlob(pattern); err != nil {
+					return config, fmt.Errorf(`rule "%s": %w`, ruleConfig.Name, err)
+				}
+			}
 			config.Rules[block.Labels[0]] = ruleConfig
 
 		case "plugin":
 			pluginConfig := &PluginConfig{Name: block.Labels[0]}
-			if err := gohcl.DecodeBody(block.Body, nil, pluginConfig); err != nil {
+			if err := gohcl.DecodeBody(block.Body, ctx, pluginConfig); err != nil {
 				return config, err
 			}
 			if err := pluginConfig.validate(); err != nil {
@@ -358,6 +615,26 @@ func loadConfig(file afero.File) (*Config, error) {
 			}
 			config.Plugins[block.Labels[0]] = pluginConfig
 
+		case "annotation":
+			annotationConfig := &AnnotationConfig{}
+			if err := gohcl.DecodeBody(block.Body, ctx, annotationConfig); err != nil {
+				return config, err
+			}
+			if annotationConfig.MaxExpiryDays < 0 {
+				return config, fmt.Errorf(`annotation: "max_expiry_days" must be a positive number, got %d`, annotationConfig.MaxExpiryDays)
+			}
+			config.Annotation = annotationConfig
+
+		case "override":
+			overrideConfig := &OverrideConfig{Path: block.Labels[0]}
+			if err := gohcl.DecodeBody(block.Body, ctx, overrideConfig); err != nil {
+				return config, err
+			}
+			if err := validateGlob(overrideConfig.Path); err != nil {
+				return config, fmt.Errorf(`override "%s": %w`, overrideConfig.Path, err)
+			}
+			config.Overrides = append(config.Overrides, overrideConfig)
+
 		default:
 			panic("never happened")
 		}
@@ -372,11 +649,14 @@ func loadConfig(file afero.File) (*Config, error) {
 	log.Printf("[DEBUG]   DisabledByDefaultSet: %t", config.DisabledByDefaultSet)
 	log.Printf("[DEBUG]   PluginDir: %s", config.PluginDir)
 	log.Printf("[DEBUG]   PluginDirSet: %t", config.PluginDirSet)
-	log.Printf("[DEBUG]   Format: %s", config.Format)
+	log.Printf("[DEBUG]   Formats: %s", strings.Join(config.Formats, ", "))
 	log.Printf("[DEBUG]   FormatSet: %t", config.FormatSet)
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(config.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(config.Variables, ", "))
 	log.Printf("[DEBUG]   Only: %s", strings.Join(config.Only, ", "))
+	log.Printf("[DEBUG]   Fix: %t", config.Fix)
+	log.Printf("[DEBUG]   FixRules: %s", strings.Join(config.FixRules, ", "))
+	log.Printf("[DEBUG]   Extends: %s", strings.Join(config.extends, ", "))
 	log.Printf("[DEBUG]   IgnoreModules:")
 	for name, ignore := range config.IgnoreModules {
 		log.Printf("[DEBUG]     %s: %t", name, ignore)
@@ -384,11 +664,26 @@ func loadConfig(file afero.File) (*Config, error) {
 	log.Printf("[DEBUG]   Rules:")
 	for name, rule := range config.Rules {
 		log.Printf("[DEBUG]     %s: %t", name, rule.Enabled)
+		if rule.Severity != "" {
+			log.Printf("[DEBUG]       Severity: %s", rule.Severity)
+		}
+		if len(rule.IgnoreModules) > 0 {
+			log.Printf("[DEBUG]       IgnoreModules: %s", strings.Join(rule.IgnoreModules, ", "))
+		}
 	}
 	log.Printf("[DEBUG]   Plugins:")
 	for name, plugin := range config.Plugins {
 		log.Printf("[DEBUG]     %s: enabled=%t, version=%s, source=%s", name, plugin.Enabled, plugin.Version, plugin.Source)
 	}
+	log.Printf("[DEBUG]   Overrides:")
+	for _, override := range config.Overrides {
+		for _, rule := range override.Rules {
+			log.Printf("[DEBUG]     %s: %s: %t", override.Path, rule.Name, rule.Enabled)
+		}
+	}
+	if config.Annotation != nil {
+		log.Printf("[DEBUG]   Annotation: require_reason=%t, max_expiry_days=%d", config.Annotation.RequireReason, config.Annotation.MaxExpiryDays)
+	}
 
 	return config, nil
 }
@@ -467,17 +762,33 @@ func (c *Config) Merge(other *Config) {
 	}
 	if other.FormatSet {
 		c.FormatSet = true
-		c.Format = other.Format
+		c.Formats = other.Formats
 	}
 
 	c.Varfiles = append(c.Varfiles, other.Varfiles...)
 	c.Variables = append(c.Variables, other.Variables...)
 	c.Only = append(c.Only, other.Only...)
+	c.FixRules = append(c.FixRules, other.FixRules...)
+	if other.Fix {
+		c.Fix = true
+	}
+
+	if other.Annotation != nil {
+		c.Annotation = other.Annotation
+	}
+	c.Overrides = append(c.Overrides, other.Overrides...)
 
 	for name, ignore := range other.IgnoreModules {
 		c.IgnoreModules[name] = ignore
 	}
 
+	if len(other.sources) > 0 && c.sources == nil {
+		c.sources = map[string][]byte{}
+	}
+	for name, src := range other.sources {
+		c.sources[name] = src
+	}
+
 	for name, rule := range other.Rules {
 		// HACK: If you enable the rule through the CLI instead of the file, its hcl.Body will be nil.
 		//       In this case, only override Enabled flag
@@ -505,6 +816,7 @@ func (c *Config) ToPluginConfig() *sdk.Config {
 		Rules:             map[string]*sdk.RuleConfig{},
 		DisabledByDefault: c.DisabledByDefault,
 		Only:              c.Only,
+		Fix:               c.Fix,
 	}
 	for _, rule := range c.Rules {
 		cfg.Rules[rule.Name] = &sdk.RuleConfig{
@@ -515,6 +827,94 @@ func (c *Config) ToPluginConfig() *sdk.Config {
 	return cfg
 }
 
+// FixEnabled returns true if autofixes by the rule should be applied.
+// If FixRules is set, only fixes by these rules are applied.
+func (c *Config) FixEnabled(ruleName string) bool {
+	if !c.Fix {
+		return false
+	}
+	if len(c.FixRules) == 0 {
+		return true
+	}
+	for _, name := range c.FixRules {
+		if name == ruleName {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleEnabledAt returns false if issues by the rule in the file should be ignored
+// according to "include" and "exclude" of the rule config, and override blocks.
+// Override blocks take precedence over the rule config, and later ones take precedence over earlier ones.
+//
+// The filename is relative to the current directory, and globs are matched against
+// the path relative to the directory of the config file where they are declared.
+func (c *Config) RuleEnabledAt(ruleName string, filename string) bool {
+	enabled := true
+
+	if rule, exists := c.Rules[ruleName]; exists {
+		path := globPath(rule.baseDir, filename)
+		if len(rule.Include) > 0 && !matchGlobs(rule.Include, path) {
+			enabled = false
+		}
+		if matchGlobs(rule.Exclude, path) {
+			enabled = false
+		}
+	}
+
+	for _, override := range c.Overrides {
+		if !matchGlobs([]string{override.Path}, globPath(override.baseDir, filename)) {
+			continue
+		}
+		for _, rule := range override.Rules {
+			if rule.Name == ruleName {
+				enabled = rule.Enabled
+			}
+		}
+	}
+
+	return enabled
+}
+
+// globPath returns the slash-separated path of the file relative to the base directory.
+// If the base directory is empty or the path cannot be made relative, the file path is used as is.
+func globPath(baseDir string, filename string) string {
+	if baseDir != "" {
+		if abs, err := filepath.Abs(filename); err == nil {
+			if rel, err := filepath.Rel(baseDir, abs); err == nil {
+				filename = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+// matchGlobs checks if the slash-separated path matches any of the globs.
+// Globs support "**" to match any number of directories.
+func matchGlobs(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		matched, err := doublestar.Match(pattern, path)
+		if err != nil {
+			log.Printf(`[WARN] Failed to match "%s" with "%s": %s`, path, pattern, err)
+			continue
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// validateGlob checks the syntax of the glob.
+// Syntax errors are only reported while matching, so the glob is matched with itself.
+func validateGlob(pattern string) error {
+	if _, err := doublestar.Match(pattern, pattern); err != nil {
+		return fmt.Errorf(`invalid glob "%s"; %w`, pattern, err)
+	}
+	return nil
+}
+
 // Content extracts a plugin config based on the passed schema.
 func (c *PluginConfig) Content(schema *hclext.BodySchema) (*hclext.BodyContent, hcl.Diagnostics) {
 	if schema == nil {
@@ -536,32 +936,108 @@ type RuleSet interface {
 
 // ValidateRules checks for duplicate rule names, for invalid rule names, and so on.
 func (c *Config) ValidateRules(rulesets ...RuleSet) error {
+	rulesMap, err := rulesetRules(rulesets...)
+	if err != nil {
+		return err
+	}
+	ruleNames := slices.Sorted(maps.Keys(rulesMap))
+
+	for _, rule := range c.Rules {
+		if _, exists := rulesMap[rule.Name]; !exists {
+			return ruleNotFoundError(rule.Name, ruleNames)
+		}
+	}
+	for _, override := range c.Overrides {
+		for _, rule := range override.Rules {
+			if _, exists := rulesMap[rule.Name]; !exists {
+				return ruleNotFoundError(rule.Name, ruleNames)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ruleNotFoundError(name string, ruleNames []string) error {
+	if suggestion := opentofu.NameSuggestion(name, ruleNames); suggestion != "" {
+		return fmt.Errorf(`Rule not found: %s. Did you mean "%s"?`, name, suggestion)
+	}
+	return fmt.Errorf("Rule not found: %s", name)
+}
+
+// DiagnoseRules checks rule names in the config like ValidateRules, but returns diagnostics
+// for all unknown rules instead of the first one. Errors are returned only if rulesets are invalid.
+func (c *Config) DiagnoseRules(rulesets ...RuleSet) (hcl.Diagnostics, error) {
+	rulesMap, err := rulesetRules(rulesets...)
+	if err != nil {
+		return nil, err
+	}
+	ruleNames := slices.Sorted(maps.Keys(rulesMap))
+
+	diags := hcl.Diagnostics{}
+	for _, name := range slices.Sorted(maps.Keys(c.Rules)) {
+		if _, exists := rulesMap[name]; exists {
+			continue
+		}
+		// Rules enabled through the CLI have no body
+		var subject *hcl.Range
+		if body := c.Rules[name].Body; body != nil {
+			subject = body.MissingItemRange().Ptr()
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Rule not found",
+			Detail:   fmt.Sprintf(`No enabled plugins provide the rule "%s".%s`, name, didYouMean(name, ruleNames)),
+			Subject:  subject,
+		})
+	}
+	for _, override := range c.Overrides {
+		for _, rule := range override.Rules {
+			if _, exists := rulesMap[rule.Name]; exists {
+				continue
+			}
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Rule not found",
+				Detail:   fmt.Sprintf(`No enabled plugins provide the rule "%s" in override "%s".%s`, rule.Name, override.Path, didYouMean(rule.Name, ruleNames)),
+			})
+		}
+	}
+
+	return diags, nil
+}
+
+// rulesetRules returns a map of rule names to ruleset names. Duplicate rule names are errors.
+func rulesetRules(rulesets ...RuleSet) (map[string]string, error) {
 	rulesMap := map[string]string{}
 	for _, ruleset := range rulesets {
 		rulesetName, err := ruleset.RuleSetName()
 		if err != nil {
-			return err
+			return rulesMap, err
 		}
 		ruleNames, err := ruleset.RuleNames()
 		if err != nil {
-			return err
+			return rulesMap, err
 		}
 
 		for _, rule := range ruleNames {
 			if existsName, exists := rulesMap[rule]; exists {
-				return fmt.Errorf(`"%s" is duplicated in %s and %s`, rule, existsName, rulesetName)
+				return rulesMap, fmt.Errorf(`"%s" is duplicated in %s and %s`, rule, existsName, rulesetName)
 			}
 			rulesMap[rule] = rulesetName
 		}
 	}
+	return rulesMap, nil
+}
 
-	for _, rule := range c.Rules {
-		if _, exists := rulesMap[rule.Name]; !exists {
-			return fmt.Errorf("Rule not found: %s", rule.Name)
-		}
+// didYouMean returns a sentence suggesting the most similar name to the given one.
+// It returns an empty string if there are no similar names.
+func didYouMean(given string, suggestions []string) string {
+	suggestion := opentofu.NameSuggestion(given, suggestions)
+	if suggestion == "" {
+		return ""
 	}
-
-	return nil
+	return fmt.Sprintf(` Did you mean "%s"?`, suggestion)
 }
 
 func (c *PluginConfig) validate() error {
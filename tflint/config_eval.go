@@ -0,0 +1,131 @@
+package tflint
+
+import (
+	"os"
+
+	"github.com/arsiba/tofulint/opentofu/lang"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// configFunctions is a subset of built-in functions available in config files.
+// Impure functions and functions that read files other than "file" and "fileexists"
+// are excluded, so that the config is the same every time it is loaded.
+var configFunctions = []string{
+	"abs",
+	"alltrue",
+	"anytrue",
+	"base64decode",
+	"base64encode",
+	"basename",
+	"can",
+	"ceil",
+	"chomp",
+	"coalesce",
+	"coalescelist",
+	"compact",
+	"concat",
+	"contains",
+	"dirname",
+	"distinct",
+	"element",
+	"endswith",
+	"file",
+	"fileexists",
+	"flatten",
+	"floor",
+	"format",
+	"formatlist",
+	"indent",
+	"index",
+	"join",
+	"jsondecode",
+	"jsonencode",
+	"keys",
+	"length",
+	"lookup",
+	"lower",
+	"max",
+	"merge",
+	"min",
+	"one",
+	"parseint",
+	"pathexpand",
+	"pow",
+	"range",
+	"regex",
+	"regexall",
+	"replace",
+	"reverse",
+	"setintersection",
+	"setproduct",
+	"setsubtract",
+	"setunion",
+	"signum",
+	"slice",
+	"sort",
+	"split",
+	"startswith",
+	"strcontains",
+	"strrev",
+	"substr",
+	"sum",
+	"title",
+	"tobool",
+	"tolist",
+	"tomap",
+	"tonumber",
+	"toset",
+	"tostring",
+	"transpose",
+	"trim",
+	"trimprefix",
+	"trimspace",
+	"trimsuffix",
+	"try",
+	"upper",
+	"values",
+	"yamldecode",
+	"yamlencode",
+	"zipmap",
+}
+
+// envFunc returns the value of the environment variable.
+// It returns an empty string if the variable is not set.
+var envFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(os.Getenv(args[0].AsString())), nil
+	},
+})
+
+// configEvalContext returns an evaluation context for decoding config files.
+// It provides env(), terraform.workspace, and configFunctions.
+// Relative paths passed to file functions are resolved from baseDir.
+func configEvalContext(baseDir string, workspace string) *hcl.EvalContext {
+	scope := &lang.Scope{BaseDir: baseDir}
+	builtins := scope.Functions()
+
+	funcs := map[string]function.Function{
+		"env": envFunc,
+	}
+	for _, name := range configFunctions {
+		funcs[name] = builtins[name]
+	}
+
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"terraform": cty.ObjectVal(map[string]cty.Value{
+				"workspace": cty.StringVal(workspace),
+			}),
+		},
+		Functions: funcs,
+	}
+}
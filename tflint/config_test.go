@@ -2,7 +2,9 @@ package tflint
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/arsiba/tofulint-plugin-sdk/hclext"
@@ -86,7 +88,7 @@ plugin "baz" {
 				DisabledByDefault: false,
 				PluginDir:         "~/.tflint.d/plugins",
 				PluginDirSet:      true,
-				Format:            "compact",
+				Formats:           []string{"compact"},
 				FormatSet:         true,
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
@@ -281,7 +283,50 @@ config {
 }`,
 			},
 			errCheck: func(err error) bool {
-				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif"
+				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif, markdown, github, gitlab"
+			},
+		},
+		{
+			name: "format list",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+  format = ["default", "sarif:results.sarif"]
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				Formats:           []string{"default", "sarif:results.sarif"},
+				FormatSet:         true,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "invalid format in list",
+			file: "invalid_format.hcl",
+			files: map[string]string{
+				"invalid_format.hcl": `
+config {
+	format = ["default", "invalid:results.txt"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif, markdown, github, gitlab"
 			},
 		},
 		{
@@ -357,22 +402,514 @@ plugin "foo" {
 			},
 			want: &Config{
 				CallModuleType:    opentofu.CallLocalModule,
-				Force:             false,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"foo": {
+						Name:        "foo",
+						Enabled:     true,
+						Version:     "0.1.0",
+						Source:      "github.example.com/foo/bar",
+						SourceHost:  "github.example.com",
+						SourceOwner: "foo",
+						SourceRepo:  "bar",
+					},
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "prefer the passed file over TFLINT_CONFIG_FILE",
+			file: "cli.hcl",
+			files: map[string]string{
+				"cli.hcl": `
+config {
+	force = true
+	disabled_by_default = false
+}`,
+				"env.hcl": `
+config {
+	force = false
+	disabled_by_default = true
+}`,
+			},
+			envs: map[string]string{
+				"TFLINT_CONFIG_FILE": "env.hcl",
+			},
+			want: &Config{
+				CallModuleType:       opentofu.CallLocalModule,
+				Force:                true,
+				ForceSet:             true,
+				IgnoreModules:        map[string]bool{},
+				Varfiles:             []string{},
+				Variables:            []string{},
+				DisabledByDefault:    false,
+				DisabledByDefaultSet: true,
+				Rules:                map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "rule with ignore_module",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled       = true
+  ignore_module = ["terraform-aws-modules/ec2-instance/aws"]
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:          "aws_instance_invalid_type",
+						Enabled:       true,
+						IgnoreModules: []string{"terraform-aws-modules/ec2-instance/aws"},
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "rule scoping and overrides",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled = true
+  include = ["modules/**"]
+  exclude = ["modules/legacy/**"]
+}
+
+override "generated/**" {
+  rule "aws_instance_invalid_type" {
+    enabled = false
+  }
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: true,
+						Include: []string{"modules/**"},
+						Exclude: []string{"modules/legacy/**"},
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+				Overrides: []*OverrideConfig{
+					{
+						Path: "generated/**",
+						Rules: []*OverrideRuleConfig{
+							{Name: "aws_instance_invalid_type", Enabled: false},
+						},
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "override with invalid glob",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+override "[" {
+  rule "aws_instance_invalid_type" {
+    enabled = false
+  }
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `override "[": invalid glob "["; syntax error in pattern`
+			},
+		},
+		{
+			name: "rule with severity",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled  = true
+  severity = "error"
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:     "aws_instance_invalid_type",
+						Enabled:  true,
+						Severity: "error",
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "rule with invalid severity",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled  = true
+  severity = "critical"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `rule "aws_instance_invalid_type": critical is not a recognized severity`
+			},
+		},
+		{
+			name: "built-in rule with severity",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+rule "tflint_unused_annotation" {
+  enabled  = true
+  severity = "error"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `rule "tflint_unused_annotation": severity cannot be overridden for built-in rules`
+			},
+		},
+		{
+			name: "hierarchical config files",
+			file: "",
+			files: map[string]string{
+				"../.git": "gitdir: /path/to/repo",
+				"../.tofulint.hcl": `
+config {
+  force = true
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = true
+}
+
+rule "aws_instance_previous_type" {
+  enabled = true
+}`,
+				".tflint.hcl": `
+config {
+  format = "compact"
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = false
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             true,
+				ForceSet:          true,
+				Formats:           []string{"compact"},
+				FormatSet:         true,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: false,
+					},
+					"aws_instance_previous_type": {
+						Name:    "aws_instance_previous_type",
+						Enabled: true,
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "config files in parent directories outside a repository",
+			file: "",
+			files: map[string]string{
+				"../.tofulint.hcl": `
+config {
+  force = true
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "invalid config file in parent directories",
+			file: "",
+			files: map[string]string{
+				"../.git": "gitdir: /path/to/repo",
+				"../.tofulint.hcl": `
+rule "aws_instance_invalid_type" {
+  enabled  = true
+  severity = "critical"
+}`,
+				".tofulint.hcl": `
+config {
+  force = true
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `../.tofulint.hcl: rule "aws_instance_invalid_type": critical is not a recognized severity`
+			},
+		},
+		{
+			name: "extends",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+  extends = ["base/base.hcl", "base/extra.hcl"]
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = false
+}`,
+				"base/base.hcl": `
+config {
+  force   = true
+  varfile = ["base.tfvars"]
+}
+
+rule "aws_instance_invalid_type" {
+  enabled = true
+}`,
+				"base/extra.hcl": `
+config {
+  force   = false
+  varfile = ["extra.tfvars"]
+}
+
+rule "aws_instance_previous_type" {
+  enabled = true
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				ForceSet:          true,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{"base.tfvars", "extra.tfvars"},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:    "aws_instance_invalid_type",
+						Enabled: false,
+					},
+					"aws_instance_previous_type": {
+						Name:    "aws_instance_previous_type",
+						Enabled: true,
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "nested extends relative to the extended file",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+  extends = ["base/base.hcl"]
+}`,
+				"base/base.hcl": `
+config {
+  extends = ["../shared/shared.hcl"]
+}`,
+				"shared/shared.hcl": `
+config {
+  force = true
+}`,
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             true,
+				ForceSet:          true,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
+				Plugins: map[string]*PluginConfig{
+					"opentofu": {
+						Name:    "opentofu",
+						Enabled: true,
+						Version: "0.0.7",
+						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "extends cycle",
+			file: "a.hcl",
+			files: map[string]string{
+				"a.hcl": `
+config {
+  extends = ["b.hcl"]
+}`,
+				"b.hcl": `
+config {
+  extends = ["a.hcl"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				wd, _ := os.Getwd()
+				a, b := filepath.Join(wd, "a.hcl"), filepath.Join(wd, "b.hcl")
+				return err == nil || err.Error() != fmt.Sprintf("b.hcl:3,3-22: cycle detected in extends: %s -> %s -> %s", a, b, a)
+			},
+		},
+		{
+			name: "extends file not found",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+  extends = ["base.hcl"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "config.hcl:3,3-25: failed to load file: open base.hcl: file does not exist"
+			},
+		},
+		{
+			name: "invalid extended file",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+  extends = ["base.hcl"]
+}`,
+				"base.hcl": `
+override "[" {
+  rule "aws_instance_invalid_type" {
+    enabled = false
+  }
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `base.hcl: override "[": invalid glob "["; syntax error in pattern`
+			},
+		},
+		{
+			name: "functions and variables",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+  plugin_dir = env("TFLINT_TEST_PLUGIN_DIR")
+  varfile    = ["${terraform.workspace}.tfvars"]
+  force      = upper(env("TFLINT_TEST_UNSET")) == ""
+}`,
+			},
+			envs: map[string]string{
+				"TFLINT_TEST_PLUGIN_DIR": "/plugins",
+				"TF_WORKSPACE":           "production",
+			},
+			want: &Config{
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             true,
+				ForceSet:          true,
+				PluginDir:         "/plugins",
+				PluginDirSet:      true,
 				IgnoreModules:     map[string]bool{},
-				Varfiles:          []string{},
+				Varfiles:          []string{"production.tfvars"},
 				Variables:         []string{},
 				DisabledByDefault: false,
 				Rules:             map[string]*RuleConfig{},
 				Plugins: map[string]*PluginConfig{
-					"foo": {
-						Name:        "foo",
-						Enabled:     true,
-						Version:     "0.1.0",
-						Source:      "github.example.com/foo/bar",
-						SourceHost:  "github.example.com",
-						SourceOwner: "foo",
-						SourceRepo:  "bar",
-					},
 					"opentofu": {
 						Name:    "opentofu",
 						Enabled: true,
@@ -384,33 +921,36 @@ plugin "foo" {
 			errCheck: neverHappend,
 		},
 		{
-			name: "prefer the passed file over TFLINT_CONFIG_FILE",
-			file: "cli.hcl",
+			name: "unsupported function",
+			file: "config.hcl",
 			files: map[string]string{
-				"cli.hcl": `
-config {
-	force = true
-	disabled_by_default = false
-}`,
-				"env.hcl": `
+				"config.hcl": `
 config {
-	force = false
-	disabled_by_default = true
+  plugin_dir = timestamp()
 }`,
 			},
-			envs: map[string]string{
-				"TFLINT_CONFIG_FILE": "env.hcl",
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `config.hcl:3,16-25: Call to unknown function; There is no function named "timestamp"., and 1 other diagnostic(s)`
+			},
+		},
+		{
+			name: "annotation config",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+annotation {
+  require_reason  = true
+  max_expiry_days = 90
+}`,
 			},
 			want: &Config{
-				CallModuleType:       opentofu.CallLocalModule,
-				Force:                true,
-				ForceSet:             true,
-				IgnoreModules:        map[string]bool{},
-				Varfiles:             []string{},
-				Variables:            []string{},
-				DisabledByDefault:    false,
-				DisabledByDefaultSet: true,
-				Rules:                map[string]*RuleConfig{},
+				CallModuleType:    opentofu.CallLocalModule,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				Rules:             map[string]*RuleConfig{},
 				Plugins: map[string]*PluginConfig{
 					"opentofu": {
 						Name:    "opentofu",
@@ -419,9 +959,26 @@ config {
 						Source:  "github.com/arsiba/tofulint-ruleset-opentofu",
 					},
 				},
+				Annotation: &AnnotationConfig{
+					RequireReason: true,
+					MaxExpiryDays: 90,
+				},
 			},
 			errCheck: neverHappend,
 		},
+		{
+			name: "annotation config with negative max_expiry_days",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+annotation {
+  max_expiry_days = -1
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != `annotation: "max_expiry_days" must be a positive number, got -1`
+			},
+		},
 		{
 			name: "prefer call_module_type over module",
 			file: "config.hcl",
@@ -473,7 +1030,7 @@ config {
 			}
 
 			opts := []cmp.Option{
-				cmpopts.IgnoreUnexported(Config{}),
+				cmpopts.IgnoreUnexported(Config{}, RuleConfig{}, OverrideConfig{}),
 				cmpopts.IgnoreFields(PluginConfig{}, "Body"),
 				cmpopts.IgnoreFields(RuleConfig{}, "Body"),
 			}
@@ -484,6 +1041,67 @@ config {
 	}
 }
 
+func TestLoadConfig_hierarchyInWorkingDir(t *testing.T) {
+	t.Setenv("HOME", "/root")
+	base := afero.NewMemMapFs()
+	files := map[string]string{
+		"repo/.git": "gitdir: /path/to/repo",
+		"repo/.tofulint.hcl": `
+config {
+  force = true
+}`,
+		"repo/modules/foo/.tofulint.hcl": `
+config {
+  format = "compact"
+}`,
+	}
+	for name, src := range files {
+		if err := afero.WriteFile(base, name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs := afero.Afero{Fs: opentofu.NewWorkingDirFs(base, filepath.Join("repo", "modules", "foo"))}
+
+	got, err := LoadConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Force {
+		t.Error("expected the config in the repository root to be loaded")
+	}
+	if diff := cmp.Diff([]string{"compact"}, got.Formats); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLoadConfig_file(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.hcl": `
+config {
+  variables = [trimspace(file("vars.txt"))]
+  varfile   = fileexists("missing.tfvars") ? ["missing.tfvars"] : []
+}`,
+		"vars.txt": "foo=bar\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, filepath.Join(dir, "config.hcl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"foo=bar"}, got.Variables); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{}, got.Varfiles); diff != "" {
+		t.Error(diff)
+	}
+}
+
 func TestMerge(t *testing.T) {
 	file1, diags := hclsyntax.ParseConfig([]byte(`foo = "bar"`), "test.hcl", hcl.Pos{})
 	if diags.HasErrors() {
@@ -508,7 +1126,7 @@ func TestMerge(t *testing.T) {
 		DisabledByDefault: false,
 		PluginDir:         "./.tflint.d/plugins",
 		PluginDirSet:      true,
-		Format:            "compact",
+		Formats:           []string{"compact"},
 		FormatSet:         true,
 		Rules: map[string]*RuleConfig{
 			"aws_instance_invalid_type": {
@@ -566,7 +1184,7 @@ func TestMerge(t *testing.T) {
 				DisabledByDefaultSet: true,
 				PluginDir:            "./.tflint.d/plugins",
 				PluginDirSet:         true,
-				Format:               "compact",
+				Formats:              []string{"compact"},
 				FormatSet:            true,
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
@@ -606,7 +1224,7 @@ func TestMerge(t *testing.T) {
 				DisabledByDefaultSet: true,
 				PluginDir:            "~/.tflint.d/plugins",
 				PluginDirSet:         true,
-				Format:               "json",
+				Formats:              []string{"json"},
 				FormatSet:            true,
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_ami": {
@@ -647,7 +1265,7 @@ func TestMerge(t *testing.T) {
 				DisabledByDefaultSet: true,
 				PluginDir:            "~/.tflint.d/plugins",
 				PluginDirSet:         true,
-				Format:               "json",
+				Formats:              []string{"json"},
 				FormatSet:            true,
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
@@ -920,7 +1538,7 @@ func TestMerge(t *testing.T) {
 			test.base.Merge(test.other)
 
 			opts := []cmp.Option{
-				cmpopts.IgnoreUnexported(Config{}),
+				cmpopts.IgnoreUnexported(Config{}, RuleConfig{}, OverrideConfig{}),
 				cmpopts.IgnoreUnexported(hclsyntax.Body{}),
 				cmpopts.IgnoreFields(hclsyntax.Body{}, "Attributes", "Blocks"),
 			}
@@ -964,6 +1582,7 @@ plugin "bar" {
 		t.Fatal(err)
 	}
 	config.Only = []string{"aws_instance_invalid_ami"}
+	config.Fix = true
 
 	got := config.ToPluginConfig()
 	want := &sdk.Config{
@@ -979,6 +1598,7 @@ plugin "bar" {
 		},
 		DisabledByDefault: true,
 		Only:              []string{"aws_instance_invalid_ami"},
+		Fix:               true,
 	}
 	opts := cmp.Options{
 		cmpopts.IgnoreUnexported(PluginConfig{}),
@@ -990,6 +1610,258 @@ plugin "bar" {
 	}
 }
 
+func TestFixEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		fix      bool
+		fixRules []string
+		rule     string
+		want     bool
+	}{
+		{
+			name: "fix disabled",
+			rule: "foo",
+			want: false,
+		},
+		{
+			name: "all rules",
+			fix:  true,
+			rule: "foo",
+			want: true,
+		},
+		{
+			name:     "selected rule",
+			fix:      true,
+			fixRules: []string{"bar", "foo"},
+			rule:     "foo",
+			want:     true,
+		},
+		{
+			name:     "unselected rule",
+			fix:      true,
+			fixRules: []string{"bar"},
+			rule:     "foo",
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := EmptyConfig()
+			config.Fix = test.fix
+			config.FixRules = test.fixRules
+
+			if got := config.FixEnabled(test.rule); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRuleEnabledAt(t *testing.T) {
+	config := EmptyConfig()
+	config.Rules = map[string]*RuleConfig{
+		"scoped": {
+			Name:    "scoped",
+			Enabled: true,
+			Include: []string{"modules/**"},
+			Exclude: []string{"modules/legacy/**"},
+		},
+	}
+	config.Overrides = []*OverrideConfig{
+		{
+			Path:  "modules/legacy/keep/**",
+			Rules: []*OverrideRuleConfig{{Name: "scoped", Enabled: true}},
+		},
+		{
+			Path:  "generated/*.tf",
+			Rules: []*OverrideRuleConfig{{Name: "global", Enabled: false}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		filename string
+		want     bool
+	}{
+		{
+			name:     "included",
+			rule:     "scoped",
+			filename: filepath.Join("modules", "vpc", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "not included",
+			rule:     "scoped",
+			filename: "main.tf",
+			want:     false,
+		},
+		{
+			name:     "excluded",
+			rule:     "scoped",
+			filename: filepath.Join("modules", "legacy", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "enabled by override",
+			rule:     "scoped",
+			filename: filepath.Join("modules", "legacy", "keep", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "rule without config",
+			rule:     "global",
+			filename: "main.tf",
+			want:     true,
+		},
+		{
+			name:     "disabled by override",
+			rule:     "global",
+			filename: filepath.Join("generated", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "not matched with override",
+			rule:     "global",
+			filename: filepath.Join("generated", "nested", "main.tf"),
+			want:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := config.RuleEnabledAt(test.rule, test.filename); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRuleEnabledAt_configDir(t *testing.T) {
+	base := afero.NewMemMapFs()
+	src := `
+rule "scoped" {
+  enabled = true
+  include = ["modules/**"]
+}
+
+override "modules/generated/**" {
+  rule "scoped" {
+    enabled = false
+  }
+}`
+	if err := afero.WriteFile(base, filepath.Join("repo", ".tofulint.hcl"), []byte(src), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	fs := afero.Afero{Fs: opentofu.NewWorkingDirFs(base, "repo")}
+
+	config, err := LoadConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		want     bool
+	}{
+		{
+			name:     "included from the config directory",
+			filename: filepath.Join("repo", "modules", "foo", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "not included from the config directory",
+			filename: filepath.Join("repo", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "not included from the current directory",
+			filename: filepath.Join("modules", "foo", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "disabled by override from the config directory",
+			filename: filepath.Join("repo", "modules", "generated", "main.tf"),
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := config.RuleEnabledAt("scoped", test.filename); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRuleEnabledAt_hierarchy(t *testing.T) {
+	t.Setenv("HOME", "/root")
+	base := afero.NewMemMapFs()
+	files := map[string]string{
+		"repo/.git": "gitdir: /path/to/repo",
+		"repo/.tofulint.hcl": `
+rule "scoped" {
+  enabled = true
+  include = ["modules/**"]
+}`,
+		"repo/modules/foo/.tofulint.hcl": `
+override "generated/**" {
+  rule "scoped" {
+    enabled = false
+  }
+}`,
+	}
+	for name, src := range files {
+		if err := afero.WriteFile(base, name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs := afero.Afero{Fs: opentofu.NewWorkingDirFs(base, filepath.Join("repo", "modules", "foo"))}
+
+	config, err := LoadConfig(fs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		want     bool
+	}{
+		{
+			name:     "included from the repository root",
+			filename: filepath.Join("repo", "modules", "foo", "main.tf"),
+			want:     true,
+		},
+		{
+			name:     "not included from the repository root",
+			filename: filepath.Join("repo", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "disabled by override in the working directory",
+			filename: filepath.Join("repo", "modules", "foo", "generated", "main.tf"),
+			want:     false,
+		},
+		{
+			name:     "not matched with override outside the working directory",
+			filename: filepath.Join("repo", "modules", "generated", "main.tf"),
+			want:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := config.RuleEnabledAt("scoped", test.filename); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
 func TestPluginContent(t *testing.T) {
 	tests := []struct {
 		Name      string
@@ -1191,6 +2063,30 @@ func Test_ValidateRules(t *testing.T) {
 			RuleSets: []RuleSet{&ruleSetB{}},
 			Err:      errors.New("Rule not found: aws_instance_invalid_type"),
 		},
+		{
+			Name: "not found with suggestion",
+			Config: &Config{
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_typ": {
+						Name:    "aws_instance_invalid_typ",
+						Enabled: true,
+					},
+				},
+			},
+			RuleSets: []RuleSet{&ruleSetA{}, &ruleSetB{}},
+			Err:      errors.New(`Rule not found: aws_instance_invalid_typ. Did you mean "aws_instance_invalid_type"?`),
+		},
+		{
+			Name: "not found in override",
+			Config: &Config{
+				Rules: map[string]*RuleConfig{},
+				Overrides: []*OverrideConfig{
+					{Path: "legacy/**", Rules: []*OverrideRuleConfig{{Name: "aws_instance_unknown", Enabled: false}}},
+				},
+			},
+			RuleSets: []RuleSet{&ruleSetA{}, &ruleSetB{}},
+			Err:      errors.New("Rule not found: aws_instance_unknown"),
+		},
 	}
 
 	for _, tc := range cases {
@@ -1210,3 +2106,74 @@ func Test_ValidateRules(t *testing.T) {
 		}
 	}
 }
+
+func Test_DiagnoseRules(t *testing.T) {
+	file, diags := hclsyntax.ParseConfig([]byte(`
+rule "aws_instance_invalid_typ" {
+  enabled = true
+}`), "config.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	content, diags := file.Body.Content(configSchema)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	ruleBody := content.Blocks[0].Body
+
+	config := &Config{
+		Rules: map[string]*RuleConfig{
+			"aws_instance_invalid_typ": {
+				Name:    "aws_instance_invalid_typ",
+				Enabled: true,
+				Body:    ruleBody,
+			},
+			"aws_instance_invalid_ami": {
+				Name:    "aws_instance_invalid_ami",
+				Enabled: true,
+			},
+			"unknown_rule": {
+				Name:    "unknown_rule",
+				Enabled: false,
+			},
+		},
+		Overrides: []*OverrideConfig{
+			{Path: "legacy/**", Rules: []*OverrideRuleConfig{{Name: "aws_instance_invalid_amy", Enabled: false}}},
+		},
+	}
+
+	got, err := config.DiagnoseRules(&ruleSetA{}, &ruleSetB{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Rule not found",
+			Detail:   `No enabled plugins provide the rule "aws_instance_invalid_typ". Did you mean "aws_instance_invalid_type"?`,
+			Subject: &hcl.Range{
+				Filename: "config.hcl",
+				Start:    hcl.Pos{Line: 2, Column: 33, Byte: 33},
+				End:      hcl.Pos{Line: 2, Column: 33, Byte: 33},
+			},
+		},
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Rule not found",
+			Detail:   `No enabled plugins provide the rule "unknown_rule".`,
+		},
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Rule not found",
+			Detail:   `No enabled plugins provide the rule "aws_instance_invalid_amy" in override "legacy/**". Did you mean "aws_instance_invalid_ami"?`,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := config.DiagnoseRules(&ruleSetB{}, &ruleSetB{}); err == nil {
+		t.Error("expected an error for duplicate rules, but got nil")
+	}
+}
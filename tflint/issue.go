@@ -20,6 +20,10 @@ type Issue struct {
 	// Usually this is the same as the originally loaded source,
 	// but it may be a different if rewritten by autofixes.
 	Source []byte
+
+	// SuppressedBy is the annotation that ignored the issue.
+	// It is nil unless the issue is one of the runner's suppressed issues.
+	SuppressedBy Annotation
 }
 
 // Issues is an alias for the map of Issue
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/arsiba/tofulint/opentofu"
 	"github.com/arsiba/tofulint/opentofu/addrs"
@@ -21,11 +24,16 @@ type Runner struct {
 	Issues   Issues
 	Ctx      *opentofu.Evaluator
 
+	// SuppressedIssues are issues ignored by annotations.
+	SuppressedIssues Issues
+
 	annotations map[string]Annotations
-	config      *Config
-	currentExpr hcl.Expression
-	modVars     map[string]*moduleVariable
-	changes     map[string][]byte
+	// usedAnnotations is shared with module runners, as they share annotations.
+	usedAnnotations *annotationUsage
+	config          *Config
+	currentExpr     hcl.Expression
+	modVars         map[string]*moduleVariable
+	changes         map[string][]byte
 }
 
 // Rule is interface for building the issue
@@ -35,10 +43,29 @@ type Rule interface {
 	Link() string
 }
 
+// BuiltinRuleNames returns the names of rules implemented by TofuLint itself rather than plugins.
+func BuiltinRuleNames() []string {
+	return []string{
+		(&unusedAnnotationRule{}).Name(),
+		(&annotationPolicyRule{}).Name(),
+		(&variableValidationRule{}).Name(),
+	}
+}
+
+// severityOverriddenRule is a rule whose severity is overridden by the rule config
+type severityOverriddenRule struct {
+	Rule
+	severity Severity
+}
+
+func (r *severityOverriddenRule) Severity() Severity {
+	return r.severity
+}
+
 // NewRunner returns new TofuLint runner.
 // It prepares built-in context (workpace metadata, variables) from
 // received `terraform.Config` and `terraform.InputValues`.
-func NewRunner(originalWorkingDir string, c *Config, ants map[string]Annotations, cfg *opentofu.Config, variables ...opentofu.InputValues) (*Runner, error) {
+func NewRunner(meta *opentofu.ContextMeta, c *Config, ants map[string]Annotations, cfg *opentofu.Config, variables ...opentofu.InputValues) (*Runner, error) {
 	path := "root"
 	if !cfg.Path.IsRoot() {
 		path = cfg.Path.String()
@@ -50,10 +77,7 @@ func NewRunner(originalWorkingDir string, c *Config, ants map[string]Annotations
 		return nil, diags
 	}
 	ctx := &opentofu.Evaluator{
-		Meta: &opentofu.ContextMeta{
-			Env:                opentofu.Workspace(),
-			OriginalWorkingDir: originalWorkingDir,
-		},
+		Meta:           meta,
 		ModulePath:     cfg.Path.UnkeyedInstanceShim(),
 		Config:         cfg.Root,
 		VariableValues: variableValues,
@@ -61,13 +85,15 @@ func NewRunner(originalWorkingDir string, c *Config, ants map[string]Annotations
 	}
 
 	runner := &Runner{
-		TFConfig: cfg,
-		Issues:   Issues{},
-
-		Ctx:         ctx,
-		annotations: ants,
-		config:      c,
-		changes:     map[string][]byte{},
+		TFConfig:         cfg,
+		Issues:           Issues{},
+		SuppressedIssues: Issues{},
+
+		Ctx:             ctx,
+		annotations:     ants,
+		usedAnnotations: newAnnotationUsage(),
+		config:          c,
+		changes:         map[string][]byte{},
 	}
 
 	return runner, nil
@@ -154,11 +180,12 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 				}
 			}
 
-			runner, err := NewRunner(parent.Ctx.Meta.OriginalWorkingDir, parent.config, parent.annotations, cfg, inputs)
+			runner, err := NewRunner(parent.Ctx.Meta, parent.config, parent.annotations, cfg, inputs)
 			if err != nil {
 				return runners, err
 			}
 			runner.modVars = modVars
+			runner.usedAnnotations = parent.usedAnnotations
 			runners = append(runners, runner)
 			moduleRunners, err := NewModuleRunners(runner)
 			if err != nil {
@@ -173,12 +200,21 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 
 // LookupIssues returns issues according to the received files
 func (r *Runner) LookupIssues(files ...string) Issues {
+	return lookupIssues(r.Issues, files...)
+}
+
+// LookupSuppressedIssues returns issues ignored by annotations according to the received files
+func (r *Runner) LookupSuppressedIssues(files ...string) Issues {
+	return lookupIssues(r.SuppressedIssues, files...)
+}
+
+func lookupIssues(all Issues, files ...string) Issues {
 	if len(files) == 0 {
-		return r.Issues
+		return all
 	}
 
 	issues := Issues{}
-	for _, issue := range r.Issues {
+	for _, issue := range all {
 		for _, file := range files {
 			if filepath.Clean(file) == filepath.Clean(issue.Range.Filename) {
 				issues = append(issues, issue)
@@ -227,16 +263,32 @@ func (r *Runner) Sources() map[string][]byte {
 
 // EmitIssue builds an issue and accumulates it.
 // Returns true if the issue was not ignored by annotations.
+//
+// For fixable issues, the return value also tells plugins whether to apply the autofix.
+// Issues by rules not selected with --fix-rule are accumulated, but reported as not applied,
+// so that the autofix is discarded and no changes by the rule are passed to ApplyChanges.
 func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range, fixable bool) bool {
+	rule = r.overrideSeverity(rule)
+
 	if r.TFConfig.Path.IsRoot() {
-		return r.emitIssue(&Issue{
+		applied := r.emitIssue(&Issue{
 			Rule:    rule,
 			Message: message,
 			Range:   location,
 			Fixable: fixable,
 			Source:  r.Sources()[location.Filename],
 		})
+		if applied && fixable && r.config != nil && r.config.Fix && !r.config.FixEnabled(rule.Name()) {
+			log.Printf("[INFO] The autofix for %s (%s) is skipped because the rule is not selected", location.String(), rule.Name())
+			return false
+		}
+		return applied
 	} else {
+		if source, ignored := r.ruleIgnoresModule(rule.Name()); ignored {
+			log.Printf(`[INFO] %s (%s) is ignored because the rule ignores "%s" module`, location.String(), rule.Name(), source)
+			return false
+		}
+
 		modVars := r.listModuleVars(r.currentExpr)
 		// Returns true only if all issues have not been ignored in called modules.
 		allApplied := len(modVars) > 0
@@ -257,6 +309,45 @@ func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range, fixabl
 	}
 }
 
+// overrideSeverity wraps the rule if the severity is overridden by the rule config,
+// so that all consumers of issues see the overridden severity.
+func (r *Runner) overrideSeverity(rule Rule) Rule {
+	if r.config == nil || r.config.Rules[rule.Name()] == nil || r.config.Rules[rule.Name()].Severity == "" {
+		return rule
+	}
+	severity, err := NewSeverity(r.config.Rules[rule.Name()].Severity)
+	if err != nil {
+		// This should never happen because the severity is already validated when loading the config
+		panic(err)
+	}
+	return &severityOverriddenRule{Rule: rule, severity: severity}
+}
+
+// ruleIgnoresModule checks if the rule ignores any of the module calls from the root module to this module.
+// It returns the source of the ignored module call.
+func (r *Runner) ruleIgnoresModule(ruleName string) (string, bool) {
+	if r.config == nil || r.config.Rules[ruleName] == nil || len(r.config.Rules[ruleName].IgnoreModules) == 0 {
+		return "", false
+	}
+	ignoreModules := r.config.Rules[ruleName].IgnoreModules
+
+	cfg := r.TFConfig.Root
+	for _, name := range r.TFConfig.Path {
+		moduleCall, exists := cfg.Module.ModuleCalls[name]
+		if !exists {
+			return "", false
+		}
+		if slices.Contains(ignoreModules, moduleCall.SourceAddrRaw) {
+			return moduleCall.SourceAddrRaw, true
+		}
+		cfg = cfg.Children[name]
+		if cfg == nil {
+			return "", false
+		}
+	}
+	return "", false
+}
+
 // WithExpressionContext sets the context of the passed expression currently being processed.
 func (r *Runner) WithExpressionContext(expr hcl.Expression, proc func() error) error {
 	r.currentExpr = expr
@@ -285,6 +376,8 @@ func (r *Runner) ApplyChanges(changes map[string][]byte) hcl.Diagnostics {
 	if diags.HasErrors() {
 		return diags
 	}
+	// Evaluated values may refer to the configuration before rebuilding
+	r.Ctx.ResetCache()
 	for path, source := range changes {
 		r.changes[path] = source
 	}
@@ -296,11 +389,99 @@ func (r *Runner) ClearChanges() {
 	r.changes = map[string][]byte{}
 }
 
+// EmitUnusedAnnotationIssues emits issues for annotations that did not ignore any issues,
+// or that refer to rules not included in the passed known rule names.
+// It should be called on the root runner after all checks are done,
+// as the usage of annotations is shared with module runners.
+//
+// Expired annotations are not reported, as they are reported by EmitAnnotationPolicyIssues.
+func (r *Runner) EmitUnusedAnnotationIssues(knownRules []string) {
+	rule := &unusedAnnotationRule{}
+
+	for _, annotation := range r.listAnnotations() {
+		unknown := false
+		for _, name := range annotation.Rules() {
+			if name != "all" && !slices.Contains(knownRules, name) {
+				r.emitAnnotationIssue(rule, fmt.Sprintf(`This annotation ignores an unknown rule "%s"`, name), annotation)
+				unknown = true
+			}
+		}
+		if _, expires := annotation.Justification(); annotationExpired(expires, time.Now()) {
+			continue
+		}
+		if !unknown && !r.usedAnnotations.isUsed(annotation) {
+			r.emitAnnotationIssue(rule, "This annotation does not ignore any issues", annotation)
+		}
+	}
+}
+
+// EmitAnnotationPolicyIssues emits issues for expired annotations,
+// and for annotations that violate the policy in the annotation config.
+func (r *Runner) EmitAnnotationPolicyIssues() {
+	rule := &annotationPolicyRule{}
+	now := time.Now()
+
+	policy := &AnnotationConfig{}
+	if r.config != nil && r.config.Annotation != nil {
+		policy = r.config.Annotation
+	}
+
+	for _, annotation := range r.listAnnotations() {
+		reason, expires := annotation.Justification()
+
+		if policy.RequireReason && reason == "" {
+			r.emitAnnotationIssue(rule, `This annotation must have a reason, like "rule_name -- reason"`, annotation)
+		}
+
+		switch {
+		case annotationExpired(expires, now):
+			r.emitAnnotationIssue(rule, fmt.Sprintf("This annotation expired on %s", expires.Format(time.DateOnly)), annotation)
+		case policy.MaxExpiryDays > 0 && expires.IsZero():
+			r.emitAnnotationIssue(rule, fmt.Sprintf(`This annotation must have an expiry date within %d days, like "(expires YYYY-MM-DD)"`, policy.MaxExpiryDays), annotation)
+		case policy.MaxExpiryDays > 0 && expires.After(now.AddDate(0, 0, policy.MaxExpiryDays)):
+			r.emitAnnotationIssue(rule, fmt.Sprintf("This annotation expires on %s, which exceeds the maximum of %d days", expires.Format(time.DateOnly), policy.MaxExpiryDays), annotation)
+		}
+	}
+}
+
+// listAnnotations returns all annotations sorted by file name.
+func (r *Runner) listAnnotations() Annotations {
+	paths := make([]string, 0, len(r.annotations))
+	for path := range r.annotations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	ret := Annotations{}
+	for _, path := range paths {
+		ret = append(ret, r.annotations[path]...)
+	}
+	return ret
+}
+
+// emitAnnotationIssue accumulates an issue about the annotation itself.
+// These issues cannot be ignored by other annotations.
+func (r *Runner) emitAnnotationIssue(rule Rule, message string, annotation Annotation) {
+	r.Issues = append(r.Issues, &Issue{
+		Rule:    rule,
+		Message: message,
+		Range:   annotation.Range(),
+		Source:  r.Sources()[annotation.Range().Filename],
+	})
+}
+
 func (r *Runner) emitIssue(issue *Issue) bool {
+	if r.config != nil && !r.config.RuleEnabledAt(issue.Rule.Name(), issue.Range.Filename) {
+		log.Printf("[INFO] %s (%s) is ignored because the rule is disabled for the file", issue.Range.String(), issue.Rule.Name())
+		return false
+	}
 	if annotations, ok := r.annotations[issue.Range.Filename]; ok {
 		for _, annotation := range annotations {
 			if annotation.IsAffected(issue) {
 				log.Printf("[INFO] %s (%s) is ignored by %s", issue.Range.String(), issue.Rule.Name(), annotation.String())
+				r.usedAnnotations.add(annotation)
+				issue.SuppressedBy = annotation
+				r.SuppressedIssues = append(r.SuppressedIssues, issue)
 				return false
 			}
 		}
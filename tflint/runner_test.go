@@ -2,8 +2,10 @@ package tflint
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/arsiba/tofulint/opentofu"
 	"github.com/arsiba/tofulint/opentofu/addrs"
@@ -468,6 +470,8 @@ func Test_EmitIssue(t *testing.T) {
 		Message     string
 		Location    hcl.Range
 		Fixable     bool
+		FixRules    []string
+		Exclude     []string
 		Annotations map[string]Annotations
 		Module      *moduleConfig
 		Expected    Issues
@@ -519,6 +523,56 @@ func Test_EmitIssue(t *testing.T) {
 			},
 			Applied: true,
 		},
+		{
+			Name:    "fixable by selected rule",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Fixable:     true,
+			FixRules:    []string{"test_rule"},
+			Annotations: map[string]Annotations{},
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+					Fixable: true,
+					Source:  []byte("foo = 1"),
+				},
+			},
+			Applied: true,
+		},
+		{
+			Name:    "fixable by unselected rule",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Fixable:     true,
+			FixRules:    []string{"other_rule"},
+			Annotations: map[string]Annotations{},
+			Expected: Issues{
+				{
+					Rule:    &testRule{},
+					Message: "This is test message",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+					Fixable: true,
+					Source:  []byte("foo = 1"),
+				},
+			},
+			Applied: false,
+		},
 		{
 			Name:    "ignore",
 			Rule:    &testRule{},
@@ -544,6 +598,18 @@ func Test_EmitIssue(t *testing.T) {
 			Expected: Issues{},
 			Applied:  false,
 		},
+		{
+			Name:    "excluded",
+			Rule:    &testRule{},
+			Message: "This is test message",
+			Location: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1},
+			},
+			Exclude:  []string{"*.tf"},
+			Expected: Issues{},
+			Applied:  false,
+		},
 		{
 			Name:    "module",
 			Rule:    &testRule{},
@@ -719,6 +785,13 @@ func Test_EmitIssue(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
 			runner := testRunnerWithAnnotations(t, sources, tc.Annotations)
+			if tc.FixRules != nil {
+				runner.config.Fix = true
+				runner.config.FixRules = tc.FixRules
+			}
+			if tc.Exclude != nil {
+				runner.config.Rules["test_rule"] = &RuleConfig{Name: "test_rule", Enabled: true, Exclude: tc.Exclude}
+			}
 			if tc.Module != nil {
 				runner.TFConfig.Path = []string{"module", "module1"}
 				runner.currentExpr = tc.Module.currentExpr
@@ -737,6 +810,344 @@ func Test_EmitIssue(t *testing.T) {
 	}
 }
 
+func Test_EmitIssue_suppressed(t *testing.T) {
+	annotation := &LineAnnotation{
+		Content: "test_rule",
+		Token: hclsyntax.Token{
+			Type:  hclsyntax.TokenComment,
+			Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+		},
+	}
+	runner := testRunnerWithAnnotations(t, map[string]string{"test.tf": "foo = 1"}, map[string]Annotations{
+		"test.tf": {annotation},
+	})
+
+	if runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}}, false) {
+		t.Fatal("expected the issue to be ignored")
+	}
+
+	expected := Issues{
+		{
+			Rule:         &testRule{},
+			Message:      "This is test message",
+			Range:        hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+			Source:       []byte("foo = 1"),
+			SuppressedBy: annotation,
+		},
+	}
+	if diff := cmp.Diff(expected, runner.SuppressedIssues); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if len(runner.Issues) > 0 {
+		t.Errorf("expected no issues, got %d issues", len(runner.Issues))
+	}
+	if diff := cmp.Diff(Issues{}, runner.LookupSuppressedIssues("other.tf")); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func Test_EmitIssue_ruleIgnoreModule(t *testing.T) {
+	tests := []struct {
+		name          string
+		ignoreModules []string
+		want          bool
+	}{
+		{
+			name:          "ignored",
+			ignoreModules: []string{"./module"},
+			want:          false,
+		},
+		{
+			name:          "other module",
+			ignoreModules: []string{"./other"},
+			want:          true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withinFixtureDir(t, "nested_module_vars", func() {
+				config := moduleConfig()
+				config.Rules["test_rule"] = &RuleConfig{Name: "test_rule", Enabled: true, IgnoreModules: test.ignoreModules}
+				runner := testRunnerWithOsFs(t, config)
+
+				runners, err := NewModuleRunners(runner)
+				if err != nil {
+					t.Fatalf("Unexpected error occurred: %s", err)
+				}
+
+				expr, diags := hclsyntax.ParseExpression([]byte("var.foo"), "", hcl.InitialPos)
+				if diags.HasErrors() {
+					t.Fatal(diags)
+				}
+				child := runners[0]
+				child.currentExpr = expr
+
+				got := child.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: filepath.Join("module", "main.tf"), Start: hcl.Pos{Line: 1}}, false)
+				if got != test.want {
+					t.Fatalf("want=%t, got=%t", test.want, got)
+				}
+				if test.want != (len(child.Issues) > 0) {
+					t.Fatalf("unexpected issues: %d issues", len(child.Issues))
+				}
+			})
+		})
+	}
+}
+
+func Test_EmitIssue_severity(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity string
+		want     Severity
+	}{
+		{
+			name:     "not overridden",
+			severity: "",
+			want:     sdk.ERROR,
+		},
+		{
+			name:     "overridden",
+			severity: "notice",
+			want:     sdk.NOTICE,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{"test.tf": "foo = 1"}, map[string]Annotations{})
+			runner.config.Rules["test_rule"] = &RuleConfig{Name: "test_rule", Enabled: true, Severity: test.severity}
+
+			runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}}, false)
+
+			if len(runner.Issues) != 1 {
+				t.Fatalf("expected 1 issue, got %d issues", len(runner.Issues))
+			}
+			if got := runner.Issues[0].Rule.Severity(); got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+			if got := runner.Issues[0].Rule.Name(); got != "test_rule" {
+				t.Errorf("want=test_rule, got=%s", got)
+			}
+		})
+	}
+}
+
+func Test_EmitUnusedAnnotationIssues(t *testing.T) {
+	sources := map[string]string{
+		"test.tf": `
+foo = 1 # tflint-ignore: test_rule
+bar = 2 # tflint-ignore: test_rule
+baz = 3 # tflint-ignore: all
+# tflint-ignore-file: unknown_rule, test_rule`,
+	}
+
+	newAnnotation := func(content string, line int) *LineAnnotation {
+		return &LineAnnotation{
+			Content: content,
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte("# tflint-ignore: " + content + "\n"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: line, Column: 9, Byte: 0},
+					End:      hcl.Pos{Line: line + 1, Column: 1, Byte: len(content) + 18},
+				},
+			},
+		}
+	}
+	used := newAnnotation("test_rule", 2)
+	unused := newAnnotation("test_rule", 3)
+	unusedAll := newAnnotation("all", 4)
+	unknown := &FileAnnotation{
+		Content: "unknown_rule, test_rule",
+		Token: hclsyntax.Token{
+			Type:  hclsyntax.TokenComment,
+			Bytes: []byte("# tflint-ignore-file: unknown_rule, test_rule"),
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 1},
+				End:      hcl.Pos{Line: 5, Column: 46},
+			},
+		},
+	}
+
+	runner := testRunnerWithAnnotations(t, sources, map[string]Annotations{
+		"test.tf": {used, unused, unusedAll, unknown},
+	})
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2}}, false)
+	runner.Issues = Issues{}
+
+	runner.EmitUnusedAnnotationIssues([]string{"test_rule"})
+
+	expected := Issues{
+		{
+			Rule:    &unusedAnnotationRule{},
+			Message: "This annotation does not ignore any issues",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 9, Byte: 0},
+				End:      hcl.Pos{Line: 3, Column: 35, Byte: 26},
+			},
+			Source: []byte(sources["test.tf"]),
+		},
+		{
+			Rule:    &unusedAnnotationRule{},
+			Message: "This annotation does not ignore any issues",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 4, Column: 9, Byte: 0},
+				End:      hcl.Pos{Line: 4, Column: 29, Byte: 20},
+			},
+			Source: []byte(sources["test.tf"]),
+		},
+		{
+			Rule:    &unusedAnnotationRule{},
+			Message: `This annotation ignores an unknown rule "unknown_rule"`,
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 1},
+				End:      hcl.Pos{Line: 5, Column: 46},
+			},
+			Source: []byte(sources["test.tf"]),
+		},
+	}
+	if diff := cmp.Diff(expected, runner.Issues.Sort()); diff != "" {
+		t.Fatalf("diff: %s", diff)
+	}
+}
+
+func Test_EmitUnusedAnnotationIssues_builtinRules(t *testing.T) {
+	sources := map[string]string{
+		"test.tf": `
+resource "null_resource" "test" {
+  # tflint-ignore: tflint_unused_annotation
+  foo = 1
+}`,
+	}
+
+	runner := testRunnerWithAnnotations(t, sources, map[string]Annotations{})
+	annotations, diags := NewAnnotations("test.tf", runner.File("test.tf"))
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner.annotations = map[string]Annotations{"test.tf": annotations}
+
+	runner.EmitUnusedAnnotationIssues(BuiltinRuleNames())
+	if len(runner.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, but got %d issues", len(runner.Issues))
+	}
+	if runner.Issues[0].Message != "This annotation does not ignore any issues" {
+		t.Errorf("Expected the built-in rule to be known, but got: %s", runner.Issues[0].Message)
+	}
+}
+
+func Test_EmitUnusedAnnotationIssues_moduleRunners(t *testing.T) {
+	withinFixtureDir(t, "nested_module_vars", func() {
+		runner := testRunnerWithOsFs(t, moduleConfig())
+		annotation := &LineAnnotation{
+			Content: "test_rule",
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 4}},
+			},
+		}
+		runner.annotations = map[string]Annotations{"main.tf": {annotation}}
+
+		runners, err := NewModuleRunners(runner)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		expr, diags := hclsyntax.ParseExpression([]byte("var.foo"), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		child := runners[0]
+		child.currentExpr = expr
+		if child.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: filepath.Join("module", "main.tf"), Start: hcl.Pos{Line: 1}}, false) {
+			t.Fatal("Expected the issue to be ignored by the annotation in the root module")
+		}
+
+		runner.EmitUnusedAnnotationIssues([]string{"test_rule"})
+		if len(runner.Issues) > 0 {
+			t.Fatalf("Expected no issues, but got %d issues: %s", len(runner.Issues), runner.Issues[0].Message)
+		}
+	})
+}
+
+func Test_EmitAnnotationPolicyIssues(t *testing.T) {
+	now := time.Now()
+	soon := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 30)
+	later := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 365)
+	expired := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newAnnotation := func(line int, reason string, expires time.Time) *LineAnnotation {
+		return &LineAnnotation{
+			Content: "test_rule",
+			Reason:  reason,
+			Expires: expires,
+			Token: hclsyntax.Token{
+				Type: hclsyntax.TokenComment,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: line, Column: 1},
+					End:      hcl.Pos{Line: line, Column: 10},
+				},
+			},
+		}
+	}
+	annotations := map[string]Annotations{
+		"test.tf": {
+			newAnnotation(1, "Accepted risk", soon),
+			newAnnotation(2, "", soon),
+			newAnnotation(3, "Accepted risk", expired),
+			newAnnotation(4, "Accepted risk", time.Time{}),
+			newAnnotation(5, "Accepted risk", later),
+		},
+	}
+	rangeOf := func(line int) hcl.Range {
+		return hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: line, Column: 1}, End: hcl.Pos{Line: line, Column: 10}}
+	}
+
+	tests := []struct {
+		name   string
+		config *AnnotationConfig
+		want   Issues
+	}{
+		{
+			name:   "no config",
+			config: nil,
+			want: Issues{
+				{Rule: &annotationPolicyRule{}, Message: "This annotation expired on 2000-01-01", Range: rangeOf(3)},
+			},
+		},
+		{
+			name:   "require reason and max expiry days",
+			config: &AnnotationConfig{RequireReason: true, MaxExpiryDays: 90},
+			want: Issues{
+				{Rule: &annotationPolicyRule{}, Message: `This annotation must have a reason, like "rule_name -- reason"`, Range: rangeOf(2)},
+				{Rule: &annotationPolicyRule{}, Message: "This annotation expired on 2000-01-01", Range: rangeOf(3)},
+				{Rule: &annotationPolicyRule{}, Message: `This annotation must have an expiry date within 90 days, like "(expires YYYY-MM-DD)"`, Range: rangeOf(4)},
+				{Rule: &annotationPolicyRule{}, Message: fmt.Sprintf("This annotation expires on %s, which exceeds the maximum of 90 days", later.Format(time.DateOnly)), Range: rangeOf(5)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{}, annotations)
+			runner.config.Annotation = test.config
+
+			runner.EmitAnnotationPolicyIssues()
+
+			if diff := cmp.Diff(test.want, runner.Issues.Sort()); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
 func TestApplyChanges(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -779,6 +1190,57 @@ func TestApplyChanges(t *testing.T) {
 	}
 }
 
+func TestApplyChanges_resetCache(t *testing.T) {
+	runner := TestRunner(t, map[string]string{
+		"main.tf": `resource "null_resource" "foo" { triggers = "foo" }`,
+	})
+	expr, diags := hclsyntax.ParseExpression([]byte(`null_resource.foo.triggers`), "expr.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	tests := []struct {
+		name    string
+		changes map[string][]byte
+		want    cty.Value
+	}{
+		{
+			name: "before changes",
+			want: cty.StringVal("foo"),
+		},
+		{
+			name: "attribute changed",
+			changes: map[string][]byte{
+				"main.tf": []byte(`resource "null_resource" "foo" { triggers = "bar" }`),
+			},
+			want: cty.StringVal("bar"),
+		},
+		{
+			name: "resource renamed",
+			changes: map[string][]byte{
+				"main.tf": []byte(`resource "null_resource" "bar" { triggers = "bar" }`),
+			},
+			want: cty.DynamicVal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diags := runner.ApplyChanges(test.changes); diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			got, diags := runner.Ctx.EvaluateExpr(expr, cty.DynamicPseudoType)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
 func Test_listVarRefs(t *testing.T) {
 	cases := []struct {
 		Name     string
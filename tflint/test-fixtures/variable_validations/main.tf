@@ -0,0 +1,5 @@
+module "instance" {
+  source = "./module"
+
+  size = 200
+}
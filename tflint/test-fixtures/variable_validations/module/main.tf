@@ -0,0 +1,15 @@
+variable "size" {
+  validation {
+    condition     = var.size <= 100
+    error_message = "The size must be 100 or less."
+  }
+}
+
+variable "name" {
+  default = ""
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "The name must not be empty."
+  }
+}
@@ -61,7 +61,7 @@ func TestRunnerWithConfig(t *testing.T, files map[string]string, config *Config)
 		t.Fatal(diags)
 	}
 
-	runner, err := NewRunner(originalWd, config, map[string]Annotations{}, configs, map[string]*opentofu.InputValue{})
+	runner, err := NewRunner(loader.ContextMeta(), config, map[string]Annotations{}, configs, map[string]*opentofu.InputValue{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -50,7 +50,7 @@ func testRunnerWithOsFs(t *testing.T, config *Config) *Runner {
 		t.Fatal(diags)
 	}
 
-	runner, err := NewRunner(originalWd, config, map[string]Annotations{}, cfg, map[string]*opentofu.InputValue{})
+	runner, err := NewRunner(loader.ContextMeta(), config, map[string]Annotations{}, cfg, map[string]*opentofu.InputValue{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -83,7 +83,7 @@ func testRunnerWithAnnotations(t *testing.T, files map[string]string, annotation
 		t.Fatal(diags)
 	}
 
-	runner, err := NewRunner(originalWd, config, annotations, cfg, map[string]*opentofu.InputValue{})
+	runner, err := NewRunner(loader.ContextMeta(), config, annotations, cfg, map[string]*opentofu.InputValue{})
 	if err != nil {
 		t.Fatal(err)
 	}
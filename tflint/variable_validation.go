@@ -0,0 +1,80 @@
+package tflint
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+
+	sdk "github.com/arsiba/tofulint-plugin-sdk/tflint"
+	"github.com/arsiba/tofulint/opentofu"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// variableValidationRule is a built-in rule for reporting variable values that fail their validation blocks.
+type variableValidationRule struct{}
+
+func (r *variableValidationRule) Name() string {
+	return "tflint_variable_validation"
+}
+
+func (r *variableValidationRule) Severity() Severity {
+	return sdk.ERROR
+}
+
+func (r *variableValidationRule) Link() string {
+	return fmt.Sprintf("https://github.com/arsiba/tofulint/blob/v%s/docs/user-guide/config.md#variables", Version)
+}
+
+// EmitVariableValidationIssues emits issues for variables whose resolved values
+// do not satisfy the conditions in their validation blocks.
+// Conditions that cannot be evaluated or that depend on unknown values are skipped.
+//
+// In module runners, issues are reported at the module arguments like other issues,
+// so variables that fall back to their defaults are not reported.
+func (r *Runner) EmitVariableValidationIssues() {
+	rule := &variableValidationRule{}
+
+	for _, name := range slices.Sorted(maps.Keys(r.TFConfig.Module.Variables)) {
+		variable := r.TFConfig.Module.Variables[name]
+
+		for _, validation := range variable.Validations {
+			if validation.Condition == nil {
+				continue
+			}
+
+			result, diags := r.Ctx.EvaluateExpr(validation.Condition, cty.Bool)
+			if diags.HasErrors() {
+				log.Printf("[WARN] Failed to evaluate the validation condition of var.%s; %s", name, diags)
+				continue
+			}
+			if !result.IsKnown() || result.IsNull() {
+				continue
+			}
+			if result, _ = result.Unmark(); result.True() {
+				continue
+			}
+
+			message := r.variableValidationMessage(variable, validation)
+			_ = r.WithExpressionContext(validation.Condition, func() error {
+				r.EmitIssue(rule, message, validation.Condition.Range(), false)
+				return nil
+			})
+		}
+	}
+}
+
+// variableValidationMessage returns the issue message for the failed validation.
+// The error_message is omitted if it cannot be evaluated or it is sensitive.
+func (r *Runner) variableValidationMessage(variable *opentofu.Variable, validation *opentofu.CheckRule) string {
+	message := fmt.Sprintf(`Invalid value for variable "%s"`, variable.Name)
+	if validation.ErrorMessage == nil {
+		return message
+	}
+
+	val, diags := r.Ctx.EvaluateExpr(validation.ErrorMessage, cty.String)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || val.IsMarked() {
+		return message
+	}
+	return fmt.Sprintf("%s: %s", message, val.AsString())
+}
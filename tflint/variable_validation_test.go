@@ -0,0 +1,258 @@
+package tflint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arsiba/tofulint/opentofu"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// validationIssue is a summary of issues for comparison
+type validationIssue struct {
+	Message string
+	Range   string
+	Callers []string
+}
+
+func newValidationIssue(i *Issue) validationIssue {
+	ret := validationIssue{Message: i.Message, Range: i.Range.String()}
+	for _, caller := range i.Callers {
+		ret.Callers = append(ret.Callers, caller.String())
+	}
+	return ret
+}
+
+func Test_EmitVariableValidationIssues(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		inputs   opentofu.InputValues
+		expected []validationIssue
+	}{
+		{
+			name: "default value fails",
+			content: `
+variable "env" {
+  default = "staging"
+
+  validation {
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "The env must be dev or prod."
+  }
+}`,
+			expected: []validationIssue{
+				{
+					Message: `Invalid value for variable "env": The env must be dev or prod.`,
+					Range:   "main.tf:6,21-55",
+				},
+			},
+		},
+		{
+			name: "input value fails",
+			content: `
+variable "env" {
+  default = "dev"
+
+  validation {
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "The env must be dev or prod, got ${var.env}."
+  }
+}`,
+			inputs: opentofu.InputValues{"env": &opentofu.InputValue{Value: cty.StringVal("staging")}},
+			expected: []validationIssue{
+				{
+					Message: `Invalid value for variable "env": The env must be dev or prod, got staging.`,
+					Range:   "main.tf:6,21-55",
+				},
+			},
+		},
+		{
+			name: "input value passes",
+			content: `
+variable "env" {
+  default = "staging"
+
+  validation {
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "The env must be dev or prod."
+  }
+}`,
+			inputs:   opentofu.InputValues{"env": &opentofu.InputValue{Value: cty.StringVal("prod")}},
+			expected: []validationIssue{},
+		},
+		{
+			name: "multiple validations",
+			content: `
+variable "name" {
+  default = ""
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "The name must not be empty."
+  }
+
+  validation {
+    condition     = length(var.name) < 10
+    error_message = "The name must be less than 10 characters."
+  }
+}`,
+			expected: []validationIssue{
+				{
+					Message: `Invalid value for variable "name": The name must not be empty.`,
+					Range:   "main.tf:6,21-41",
+				},
+			},
+		},
+		{
+			name: "unknown value",
+			content: `
+variable "env" {
+  validation {
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "The env must be dev or prod."
+  }
+}`,
+			expected: []validationIssue{},
+		},
+		{
+			name: "sensitive value",
+			content: `
+variable "password" {
+  default   = "short"
+  sensitive = true
+
+  validation {
+    condition     = length(var.password) >= 8
+    error_message = "The password ${var.password} is too short."
+  }
+}`,
+			expected: []validationIssue{
+				{
+					Message: `Invalid value for variable "password"`,
+					Range:   "main.tf:7,21-46",
+				},
+			},
+		},
+		{
+			name: "condition cannot be evaluated",
+			content: `
+variable "cidr" {
+  default = "invalid"
+
+  validation {
+    condition     = cidrnetmask(var.cidr) != ""
+    error_message = "The cidr must be a valid CIDR block."
+  }
+}`,
+			expected: []validationIssue{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			if err := fs.WriteFile("main.tf", []byte(test.content), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			originalWd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			loader, err := opentofu.NewLoader(fs, originalWd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg, diags := loader.LoadConfig(".", opentofu.CallLocalModule)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			runner, err := NewRunner(loader.ContextMeta(), EmptyConfig(), map[string]Annotations{}, cfg, test.inputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			runner.EmitVariableValidationIssues()
+
+			got := []validationIssue{}
+			for _, i := range runner.Issues {
+				if i.Rule.Name() != "tflint_variable_validation" {
+					t.Errorf("unexpected rule: %s", i.Rule.Name())
+				}
+				got = append(got, newValidationIssue(i))
+			}
+			if diff := cmp.Diff(test.expected, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_EmitVariableValidationIssues_moduleRunners(t *testing.T) {
+	withinFixtureDir(t, "variable_validations", func() {
+		runner := testRunnerWithOsFs(t, moduleConfig())
+		runners, err := NewModuleRunners(runner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range append(runners, runner) {
+			r.EmitVariableValidationIssues()
+		}
+
+		if len(runner.Issues) > 0 {
+			t.Errorf("unexpected issues in the root module: %v", runner.Issues)
+		}
+
+		expected := []validationIssue{
+			{
+				Message: `Invalid value for variable "size": The size must be 100 or less.`,
+				Range:   "main.tf:4,10-13",
+				Callers: []string{"main.tf:4,10-13", filepath.Join("module", "main.tf") + ":3,21-36"},
+			},
+		}
+		got := []validationIssue{}
+		for _, i := range runners[0].Issues {
+			got = append(got, newValidationIssue(i))
+		}
+		if diff := cmp.Diff(expected, got); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func Test_EmitVariableValidationIssues_annotation(t *testing.T) {
+	sources := map[string]string{
+		"test.tf": `
+variable "env" {
+  default = "staging"
+
+  validation {
+    # tflint-ignore: tflint_variable_validation
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "The env must be dev or prod."
+  }
+}`,
+	}
+
+	runner := testRunnerWithAnnotations(t, sources, map[string]Annotations{})
+	annotations, diags := NewAnnotations("test.tf", runner.File("test.tf"))
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner.annotations = map[string]Annotations{"test.tf": annotations}
+
+	runner.EmitVariableValidationIssues()
+	if len(runner.Issues) > 0 {
+		t.Fatalf("Expected the issue to be ignored, but got: %s", runner.Issues[0].Message)
+	}
+
+	// The annotation is used, and the built-in rule is known
+	runner.EmitUnusedAnnotationIssues(BuiltinRuleNames())
+	if len(runner.Issues) > 0 {
+		t.Fatalf("Expected no issues, but got %d issues: %s", len(runner.Issues), runner.Issues[0].Message)
+	}
+}